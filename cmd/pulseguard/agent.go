@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/umutaraz/pulseguard/internal/agent"
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/monitor/pinger"
+	"github.com/umutaraz/pulseguard/internal/monitor/scheduler"
+)
+
+// runAgent runs `pulseguard agent`: no database or API, only the checkers
+// working through the services the server assigns to agent.location.
+func runAgent(cfg *config.Config) {
+	slog.Info("Starting PulseGuard agent", "location", cfg.Agent.Location, "server", cfg.Agent.ServerURL)
+
+	checkers := pinger.NewDefaultRegistry()
+	checkers.Register(domain.ServiceTypeHTTP, pinger.NewHTTPPinger().WithSecurityPolicy(cfg.Security))
+	checkers.Register(domain.ServiceTypeExec, pinger.NewExecChecker(cfg.Exec))
+	engine := scheduler.NewMonitoringEngine(nil, checkers, cfg.Scheduler)
+
+	a, err := agent.NewAgent(cfg.Agent, engine)
+	if err != nil {
+		log.Fatalf("Invalid agent configuration: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := a.Run(ctx); err != nil {
+		log.Fatalf("Agent failed: %v", err)
+	}
+	slog.Info("Agent exited")
+}
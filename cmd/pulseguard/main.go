@@ -9,13 +9,13 @@ import (
 	"os/signal"
 	"syscall"
 
-	"time"
-	
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	memory_bus "github.com/umutaraz/pulseguard/internal/adapter/bus/memory"
+	redis_bus "github.com/umutaraz/pulseguard/internal/adapter/bus/redis"
+	redis_cluster "github.com/umutaraz/pulseguard/internal/adapter/cluster/redis"
 	"github.com/umutaraz/pulseguard/internal/adapter/handler/http"
 	"github.com/umutaraz/pulseguard/internal/adapter/handler/websocket"
-	redis_bus "github.com/umutaraz/pulseguard/internal/adapter/bus/redis"
-	memory_bus "github.com/umutaraz/pulseguard/internal/adapter/bus/memory"
 	"github.com/umutaraz/pulseguard/internal/adapter/notification/slack"
 	"github.com/umutaraz/pulseguard/internal/adapter/storage/postgres"
 	"github.com/umutaraz/pulseguard/internal/config"
@@ -33,6 +33,11 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 	logger.InitLogger(cfg.App.LogLevel)
+
+	if len(os.Args) > 1 && os.Args[1] == "agent" {
+		runAgent(cfg)
+		return
+	}
 	slog.Info("Starting PulseGuard", "env", cfg.App.Environment)
 
 	ctx := context.Background()
@@ -44,18 +49,33 @@ func main() {
 
 	repo := postgres.NewPostgresServiceRepository(dbPool)
 	metricRepo := postgres.NewPostgresMetricRepository(dbPool)
+	heartbeatRepo := postgres.NewPostgresHeartbeatRepository(dbPool)
+	contentRepo := postgres.NewPostgresContentSnapshotRepository(dbPool)
 
 	slackService := slack.NewSlackService(cfg.Notification.SlackWebhookURL)
 
 	// --- Event Bus Strategy (Hybrid) ---
 	var eventBus ports.EventBus
-	
+	var membership ports.ClusterMembership
+
 	// Try Redis first
 	if cfg.Redis.Addr != "" {
 		rbus, err := redis_bus.NewRedisEventBus(cfg.Redis)
 		if err == nil {
 			eventBus = rbus
 			slog.Info("Event Bus: Redis (Distributed)")
+
+			nodeID := cfg.Cluster.NodeID
+			if nodeID == "" {
+				host, _ := os.Hostname()
+				nodeID = host + "-" + uuid.NewString()[:8]
+			}
+			if m, err := redis_cluster.NewRedisMembership(cfg.Redis, nodeID, cfg.Cluster.HeartbeatTTL); err == nil {
+				membership = m
+				slog.Info("Cluster: Redis sharding enabled", "node", nodeID)
+			} else {
+				slog.Warn("Cluster membership unavailable, running standalone", "error", err)
+			}
 		} else {
 			slog.Warn("Redis unavailable, falling back to Memory", "error", err)
 		}
@@ -67,14 +87,21 @@ func main() {
 		slog.Info("Event Bus: In-Memory (Standalone)")
 	}
 
-	httpPinger := pinger.NewHTTPPinger(5 * time.Second)
-	engine := scheduler.NewMonitoringEngine(repo, httpPinger)
+	checkers := pinger.NewDefaultRegistry()
+	checkers.Register(domain.ServiceTypePush, pinger.NewHeartbeatChecker(heartbeatRepo))
+	checkers.Register(domain.ServiceTypeHTTP, pinger.NewHTTPPinger().WithSecurityPolicy(cfg.Security))
+	checkers.Register(domain.ServiceTypeExec, pinger.NewExecChecker(cfg.Exec))
+	engine := scheduler.NewMonitoringEngine(repo, checkers, cfg.Scheduler)
+	if membership != nil {
+		engine.SetMembership(membership, cfg.Cluster.RebalanceInterval)
+	}
 
 	if err := engine.LoadAndStart(ctx); err != nil {
 		slog.Error("Failed to load services from DB", "error", err)
 	}
 
 	analyzer := service.NewAnalyzerService(repo, metricRepo, slackService)
+	contentService := service.NewContentChangeService(repo, contentRepo, eventBus, slackService)
 	hub := websocket.NewHub()
 	go hub.Run()
 
@@ -90,18 +117,43 @@ func main() {
 		}
 	}()
 
-	engine.SetResultHandler(func(result domain.CheckResult) {
+	go func() {
+		ch, err := eventBus.SubscribeContentChanges(context.Background())
+		if err != nil {
+			slog.Error("Failed to subscribe to content changes", "error", err)
+			return
+		}
+		for change := range ch {
+			hub.BroadcastContentChange(change)
+		}
+	}()
+
+	handleResult := func(result domain.CheckResult) {
 		// 1. Analyze (State Change & Alerts)
 		go analyzer.AnalyzeResult(context.Background(), result)
-		
+
 		// 2. Publish (Distributed Broadcast)
 		if err := eventBus.PublishCheckResult(context.Background(), result); err != nil {
 			slog.Error("Failed to publish to redis", "error", err)
 		}
+	}
+
+	engine.SetResultHandler(func(result domain.CheckResult) {
+		result.Location = cfg.App.Location
+
+		// Content change detection, independent of the status. Only the
+		// server's own checks are tracked, agents may see regional content.
+		go contentService.Track(context.Background(), result)
+
+		handleResult(result)
 	})
+	agentService := service.NewAgentService(repo, cfg.Agent.Token, cfg.App.Location, handleResult)
 
-	monitorService := service.NewMonitorService(repo, metricRepo, engine)
+	monitorService := service.NewMonitorService(repo, metricRepo, engine, cfg.App.Location)
 	serviceHandler := http.NewServiceHandler(monitorService)
+	heartbeatHandler := http.NewHeartbeatHandler(service.NewHeartbeatService(repo, heartbeatRepo))
+	contentHandler := http.NewContentHandler(contentService)
+	agentHandler := http.NewAgentHandler(agentService)
 
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  cfg.Server.ReadTimeout,
@@ -109,8 +161,8 @@ func main() {
 		AppName:      cfg.App.Name,
 	})
 
-	http.SetupRouter(app, serviceHandler)
-	
+	http.SetupRouter(app, serviceHandler, heartbeatHandler, contentHandler, agentHandler)
+
 	app.Use("/ws", websocket.UpgradeMiddleware)
 	app.Get("/ws", websocket.NewWebSocketHandler(hub))
 
@@ -129,6 +181,7 @@ func main() {
 	if err := app.Shutdown(); err != nil {
 		slog.Error("Server forced to shutdown", "error", err)
 	}
+	engine.Shutdown()
 
 	slog.Info("Server exited")
 }
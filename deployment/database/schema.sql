@@ -5,8 +5,10 @@ CREATE TABLE IF NOT EXISTS services (
     name VARCHAR(255) NOT NULL,
     url TEXT NOT NULL,
     interval_seconds INTEGER NOT NULL DEFAULT 60,
+    timeout BIGINT NOT NULL DEFAULT 0,
     type VARCHAR(50) NOT NULL DEFAULT 'HTTP',
     thresholds JSONB NOT NULL DEFAULT '{"latency_warning": 500000000, "latency_critical": 2000000000}',
+    config JSONB NOT NULL DEFAULT '{}',
     status VARCHAR(50) NOT NULL DEFAULT 'UNKNOWN',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
@@ -19,7 +21,28 @@ CREATE TABLE IF NOT EXISTS checks (
     status_code INTEGER,
     latency_ns BIGINT NOT NULL,
     success BOOLEAN NOT NULL,
-    error_message TEXT
+    failure_kind VARCHAR(50),
+    error_message TEXT,
+    details JSONB,
+    location VARCHAR(100)
 );
 
 CREATE INDEX idx_checks_service_date ON checks(service_id, checked_at DESC);
+
+CREATE TABLE IF NOT EXISTS heartbeats (
+    service_id UUID PRIMARY KEY REFERENCES services(id) ON DELETE CASCADE,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    duration BIGINT NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS content_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    hash VARCHAR(64) NOT NULL,
+    content TEXT NOT NULL,
+    captured_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_content_snapshots_service ON content_snapshots(service_id, captured_at DESC);
@@ -10,6 +10,7 @@ import (
 type MemoryEventBus struct {
 	mu          sync.RWMutex
 	subscribers []chan domain.CheckResult
+	contentSubs []chan domain.ContentChange
 }
 
 func NewMemoryEventBus() *MemoryEventBus {
@@ -42,3 +43,26 @@ func (m *MemoryEventBus) SubscribeCheckResults(ctx context.Context) (<-chan doma
 
 	return ch, nil
 }
+
+func (m *MemoryEventBus) PublishContentChange(ctx context.Context, change domain.ContentChange) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, ch := range m.contentSubs {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+	return nil
+}
+
+func (m *MemoryEventBus) SubscribeContentChanges(ctx context.Context) (<-chan domain.ContentChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch := make(chan domain.ContentChange, 100)
+	m.contentSubs = append(m.contentSubs, ch)
+
+	return ch, nil
+}
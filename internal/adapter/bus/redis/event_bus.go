@@ -11,7 +11,10 @@ import (
 	"github.com/umutaraz/pulseguard/internal/core/domain"
 )
 
-const ChannelName = "pulseguard:checks"
+const (
+	ChannelName        = "pulseguard:checks"
+	ContentChannelName = "pulseguard:content"
+)
 
 type RedisEventBus struct {
 	client *redis.Client
@@ -41,7 +44,7 @@ func (r *RedisEventBus) PublishCheckResult(ctx context.Context, result domain.Ch
 
 func (r *RedisEventBus) SubscribeCheckResults(ctx context.Context) (<-chan domain.CheckResult, error) {
 	sub := r.client.Subscribe(ctx, ChannelName)
-	
+
 	if _, err := sub.Receive(ctx); err != nil {
 		return nil, err
 	}
@@ -65,3 +68,38 @@ func (r *RedisEventBus) SubscribeCheckResults(ctx context.Context) (<-chan domai
 
 	return outCh, nil
 }
+
+func (r *RedisEventBus) PublishContentChange(ctx context.Context, change domain.ContentChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(ctx, ContentChannelName, data).Err()
+}
+
+func (r *RedisEventBus) SubscribeContentChanges(ctx context.Context) (<-chan domain.ContentChange, error) {
+	sub := r.client.Subscribe(ctx, ContentChannelName)
+
+	if _, err := sub.Receive(ctx); err != nil {
+		return nil, err
+	}
+
+	ch := sub.Channel()
+	outCh := make(chan domain.ContentChange)
+
+	go func() {
+		defer close(outCh)
+		defer sub.Close()
+
+		for msg := range ch {
+			var change domain.ContentChange
+			if err := json.Unmarshal([]byte(msg.Payload), &change); err != nil {
+				slog.Error("Redis: Failed to unmarshal content change", "error", err)
+				continue
+			}
+			outCh <- change
+		}
+	}()
+
+	return outCh, nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Registry is an in-process stand-in for the cluster store. Memberships
+// created from the same registry see each other, which lets several engines
+// share work inside one process.
+type Registry struct {
+	mu    sync.RWMutex
+	nodes map[string]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		nodes: make(map[string]struct{}),
+	}
+}
+
+// Member returns the membership of nodeID in the registry.
+func (r *Registry) Member(nodeID string) *MemoryMembership {
+	return &MemoryMembership{registry: r, nodeID: nodeID}
+}
+
+type MemoryMembership struct {
+	registry *Registry
+	nodeID   string
+}
+
+func (m *MemoryMembership) NodeID() string {
+	return m.nodeID
+}
+
+// Join adds the node until ctx is done, as if its heartbeat stopped.
+func (m *MemoryMembership) Join(ctx context.Context) error {
+	m.registry.mu.Lock()
+	m.registry.nodes[m.nodeID] = struct{}{}
+	m.registry.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.Leave(context.Background())
+	}()
+	return nil
+}
+
+func (m *MemoryMembership) Leave(ctx context.Context) error {
+	m.registry.mu.Lock()
+	defer m.registry.mu.Unlock()
+	delete(m.registry.nodes, m.nodeID)
+	return nil
+}
+
+func (m *MemoryMembership) Members(ctx context.Context) ([]string, error) {
+	m.registry.mu.RLock()
+	defer m.registry.mu.RUnlock()
+
+	members := make([]string, 0, len(m.registry.nodes))
+	for id := range m.registry.nodes {
+		members = append(members, id)
+	}
+	sort.Strings(members)
+	return members, nil
+}
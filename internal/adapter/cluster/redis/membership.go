@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/umutaraz/pulseguard/internal/config"
+)
+
+// NodeKeyPrefix namespaces the heartbeat key of every node.
+const NodeKeyPrefix = "pulseguard:nodes:"
+
+// DefaultHeartbeatTTL is used when no TTL is configured.
+const DefaultHeartbeatTTL = 15 * time.Second
+
+// RedisMembership announces a node through a key that expires unless it is
+// refreshed, so a node that dies drops out after one TTL.
+type RedisMembership struct {
+	client *redis.Client
+	nodeID string
+	ttl    time.Duration
+}
+
+func NewRedisMembership(cfg config.RedisConfig, nodeID string, ttl time.Duration) (*RedisMembership, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultHeartbeatTTL
+	}
+	return &RedisMembership{client: client, nodeID: nodeID, ttl: ttl}, nil
+}
+
+func (r *RedisMembership) NodeID() string {
+	return r.nodeID
+}
+
+// Join writes the node key and refreshes it every third of the TTL.
+func (r *RedisMembership) Join(ctx context.Context) error {
+	if err := r.heartbeat(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(r.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.heartbeat(ctx); err != nil && ctx.Err() == nil {
+					slog.Error("Cluster: Failed to refresh node heartbeat", "node", r.nodeID, "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *RedisMembership) Leave(ctx context.Context) error {
+	return r.client.Del(ctx, NodeKeyPrefix+r.nodeID).Err()
+}
+
+func (r *RedisMembership) Members(ctx context.Context) ([]string, error) {
+	var members []string
+	iter := r.client.Scan(ctx, 0, NodeKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		members = append(members, strings.TrimPrefix(iter.Val(), NodeKeyPrefix))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list cluster nodes: %w", err)
+	}
+	return members, nil
+}
+
+func (r *RedisMembership) heartbeat(ctx context.Context) error {
+	return r.client.Set(ctx, NodeKeyPrefix+r.nodeID, time.Now().Unix(), r.ttl).Err()
+}
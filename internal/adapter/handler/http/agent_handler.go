@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/service"
+)
+
+// LocationHeader names the location an agent reports from.
+const LocationHeader = "X-PulseGuard-Location"
+
+type AgentHandler struct {
+	svc *service.AgentService
+}
+
+func NewAgentHandler(svc *service.AgentService) *AgentHandler {
+	return &AgentHandler{
+		svc: svc,
+	}
+}
+
+// Authenticate guards the agent endpoints: agents send the shared token as a
+// bearer token and their location in LocationHeader.
+func (h *AgentHandler) Authenticate(c *fiber.Ctx) error {
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing bearer token"})
+	}
+	location := c.Get(LocationHeader)
+	if location == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": LocationHeader + " header is required"})
+	}
+	if err := h.svc.Authenticate(token, location); err != nil {
+		switch {
+		case errors.Is(err, service.ErrAgentsDisabled):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, service.ErrReservedLocation):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Next()
+}
+
+func (h *AgentHandler) Register(c *fiber.Ctx) error {
+	agent, err := h.svc.Register(c.Get(LocationHeader), c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(agent)
+}
+
+// Checks returns the services assigned to the agent's location.
+func (h *AgentHandler) Checks(c *fiber.Ctx) error {
+	services, err := h.svc.AssignedServices(c.Context(), c.Get(LocationHeader), c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(services)
+}
+
+// SubmitResults accepts a batch of check results from the agent.
+func (h *AgentHandler) SubmitResults(c *fiber.Ctx) error {
+	var results []domain.CheckResult
+	if err := c.BodyParser(&results); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	accepted := h.svc.SubmitResults(c.Context(), c.Get(LocationHeader), c.IP(), results)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"accepted": accepted,
+		"skipped":  len(results) - accepted,
+	})
+}
+
+// List returns the agents that registered since the server started.
+func (h *AgentHandler) List(c *fiber.Ctx) error {
+	agents := h.svc.ListAgents()
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"agents": agents,
+		"count":  len(agents),
+	})
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/core/service"
+)
+
+type ContentHandler struct {
+	svc *service.ContentChangeService
+}
+
+func NewContentHandler(svc *service.ContentChangeService) *ContentHandler {
+	return &ContentHandler{
+		svc: svc,
+	}
+}
+
+// List returns the stored content snapshots of a watched service, without
+// their content; Get returns one snapshot in full.
+func (h *ContentHandler) List(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid service id"})
+	}
+
+	snapshots, err := h.svc.GetSnapshots(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"service_id": id,
+		"snapshots":  snapshots,
+		"count":      len(snapshots),
+	})
+}
+
+// Get returns the snapshot with the hash in the path, content included.
+func (h *ContentHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid service id"})
+	}
+
+	snapshot, err := h.svc.GetSnapshot(c.Context(), id, c.Params("hash"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if snapshot == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "snapshot not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(snapshot)
+}
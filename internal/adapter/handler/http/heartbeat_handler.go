@@ -0,0 +1,49 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/umutaraz/pulseguard/internal/core/service"
+)
+
+type HeartbeatHandler struct {
+	svc *service.HeartbeatService
+}
+
+func NewHeartbeatHandler(svc *service.HeartbeatService) *HeartbeatHandler {
+	return &HeartbeatHandler{
+		svc: svc,
+	}
+}
+
+// HeartbeatRequest is optional; jobs may also pass the same fields as query parameters.
+type HeartbeatRequest struct {
+	Status   string  `json:"status" query:"status"`     // success (default) or fail
+	Duration float64 `json:"duration" query:"duration"` // Seconds
+	Message  string  `json:"message" query:"message"`
+}
+
+func (h *HeartbeatHandler) Receive(c *fiber.Ctx) error {
+	var req HeartbeatRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid query parameters"})
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+
+	duration := time.Duration(req.Duration * float64(time.Second))
+	heartbeat, err := h.svc.RecordHeartbeat(c.Context(), c.Params("token"), req.Status, duration, req.Message)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownHeartbeatToken) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(heartbeat)
+}
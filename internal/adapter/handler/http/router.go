@@ -6,7 +6,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
-func SetupRouter(app *fiber.App, handler *ServiceHandler) {
+func SetupRouter(app *fiber.App, handler *ServiceHandler, heartbeatHandler *HeartbeatHandler, contentHandler *ContentHandler, agentHandler *AgentHandler) {
 	app.Use(logger.New())
 	app.Use(cors.New())
 
@@ -15,8 +15,20 @@ func SetupRouter(app *fiber.App, handler *ServiceHandler) {
 	services := api.Group("/services")
 	services.Post("/", handler.Register)
 	services.Get("/", handler.List)
+	services.Put("/:id", handler.Update)
 	services.Delete("/:id", handler.Delete)
 	services.Get("/:id/metrics", handler.GetMetrics)
+	services.Get("/:id/content", contentHandler.List)
+	services.Get("/:id/content/:hash", contentHandler.Get)
+
+	api.Post("/heartbeat/:token", heartbeatHandler.Receive)
+	api.Get("/scheduler/stats", handler.SchedulerStats)
+
+	agents := api.Group("/agents")
+	agents.Get("/", agentHandler.List)
+	agents.Post("/register", agentHandler.Authenticate, agentHandler.Register)
+	agents.Get("/checks", agentHandler.Authenticate, agentHandler.Checks)
+	agents.Post("/results", agentHandler.Authenticate, agentHandler.SubmitResults)
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.SendString("OK")
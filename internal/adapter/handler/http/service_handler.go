@@ -3,6 +3,7 @@ package http
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
 	"github.com/umutaraz/pulseguard/internal/core/service"
 )
 
@@ -19,8 +20,26 @@ func NewServiceHandler(svc *service.MonitorService) *ServiceHandler {
 type CreateServiceRequest struct {
 	Name         string `json:"name"`
 	URL          string `json:"url"`
+	Type         string `json:"type"`     // Defaults to HTTP
 	Interval     int    `json:"interval"` // Seconds
+	Timeout      int    `json:"timeout"`  // Seconds, defaults to 5 or the interval if shorter
 	SlackEnabled bool   `json:"slack_enabled"`
+
+	Thresholds *domain.ServiceThresholds `json:"thresholds"` // Durations in nanoseconds
+	Config     domain.CheckConfig        `json:"config"`     // Type-specific settings
+}
+
+func (r CreateServiceRequest) toSpec() service.ServiceSpec {
+	return service.ServiceSpec{
+		Name:         r.Name,
+		URL:          r.URL,
+		Type:         r.Type,
+		Interval:     r.Interval,
+		Timeout:      r.Timeout,
+		SlackEnabled: r.SlackEnabled,
+		Thresholds:   r.Thresholds,
+		Config:       r.Config,
+	}
 }
 
 func (h *ServiceHandler) Register(c *fiber.Ctx) error {
@@ -29,12 +48,33 @@ func (h *ServiceHandler) Register(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
 
-	result, err := h.svc.RegisterService(c.Context(), req.Name, req.URL, req.Interval, req.SlackEnabled)
+	result, err := h.svc.RegisterService(c.Context(), req.toSpec())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(result)
+	return c.Status(fiber.StatusCreated).JSON(result.Redacted())
+}
+
+// Update replaces a service's definition. Secrets may be sent back in the
+// redacted form the API returns them in.
+func (h *ServiceHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid service id"})
+	}
+
+	var req CreateServiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	result, err := h.svc.UpdateService(c.Context(), id, req.toSpec())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result.Redacted())
 }
 
 func (h *ServiceHandler) List(c *fiber.Ctx) error {
@@ -43,9 +83,15 @@ func (h *ServiceHandler) List(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	// Never expose credentials embedded in targets such as DSNs
+	redacted := make([]*domain.Service, len(services))
+	for i, svc := range services {
+		redacted[i] = svc.Redacted()
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": services,
-		"count": len(services),
+		"data":  redacted,
+		"count": len(redacted),
 	})
 }
 
@@ -65,10 +111,20 @@ func (h *ServiceHandler) GetMetrics(c *fiber.Ctx) error {
 	if err != nil {
 	}
 
+	// Latest certificate seen, if the service speaks TLS
+	var tlsInfo *domain.TLSInfo
+	for _, m := range metrics {
+		if m.TLS != nil {
+			tlsInfo = m.TLS
+			break
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"service_id": id,
 		"history":    metrics,
 		"stats":      stats,
+		"tls":        tlsInfo,
 	})
 }
 
@@ -85,3 +141,7 @@ func (h *ServiceHandler) Delete(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+func (h *ServiceHandler) SchedulerStats(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.svc.GetSchedulerStats())
+}
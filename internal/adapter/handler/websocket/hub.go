@@ -58,6 +58,21 @@ func (h *Hub) Run() {
 	}
 }
 
+// Event wraps messages other than check results, which are sent bare so
+// existing clients keep working. Clients tell them apart by Type.
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// EventContentChange carries a domain.ContentChange.
+const EventContentChange = "content_change"
+
+// BroadcastContentChange pumps a ContentChange to all connected clients.
+func (h *Hub) BroadcastContentChange(change domain.ContentChange) {
+	h.broadcast <- Event{Type: EventContentChange, Data: change}
+}
+
 // BroadcastCheckResult pumps a CheckResult to all connected clients.
 func (h *Hub) BroadcastCheckResult(result domain.CheckResult) {
 	h.broadcast <- result
@@ -54,11 +54,46 @@ func (s *SlackService) NotifyStatusChange(ctx context.Context, service *domain.S
 			{
 				Color: color,
 				Title: fmt.Sprintf("%s -> %s", oldStatus, newStatus),
-				Text:  fmt.Sprintf("Service: %s\nURL: %s\nTime: %s", service.Name, service.URL, time.Now().Format(time.RFC3339)),
+				Text:  fmt.Sprintf("Service: %s\nURL: %s\nTime: %s", service.Name, service.DisplayURL(), time.Now().Format(time.RFC3339)),
 			},
 		},
 	}
 
+	if err := s.post(ctx, msg); err != nil {
+		return err
+	}
+
+	slog.Info("Notification sent to Slack", "service", service.Name, "status", newStatus)
+	return nil
+}
+
+// NotifyContentChange reports that a watched page changed. It is sent
+// regardless of the service's status, which the change does not affect.
+func (s *SlackService) NotifyContentChange(ctx context.Context, service *domain.Service, change domain.ContentChange) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+
+	msg := slackMessage{
+		Text: fmt.Sprintf("Content Changed: *%s*", service.Name),
+		Attachments: []attachment{
+			{
+				Color: "#6f42c1", // Purple
+				Title: fmt.Sprintf("%s -> %s", shortHash(change.PreviousHash), shortHash(change.Hash)),
+				Text:  fmt.Sprintf("Service: %s\nURL: %s\nTime: %s", service.Name, service.DisplayURL(), change.DetectedAt.Format(time.RFC3339)),
+			},
+		},
+	}
+
+	if err := s.post(ctx, msg); err != nil {
+		return err
+	}
+
+	slog.Info("Content change sent to Slack", "service", service.Name, "hash", change.Hash)
+	return nil
+}
+
+func (s *SlackService) post(ctx context.Context, msg slackMessage) error {
 	payload, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal slack message: %w", err)
@@ -80,6 +115,12 @@ func (s *SlackService) NotifyStatusChange(ctx context.Context, service *domain.S
 		return fmt.Errorf("slack API returned error status: %d", resp.StatusCode)
 	}
 
-	slog.Info("Notification sent to Slack", "service", service.Name, "status", newStatus)
 	return nil
 }
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
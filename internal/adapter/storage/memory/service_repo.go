@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/umutaraz/pulseguard/internal/core/domain"
@@ -38,6 +39,18 @@ func (r *InMemoryServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	return service, nil
 }
 
+func (r *InMemoryServiceRepository) GetByPushToken(ctx context.Context, token string) (*domain.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.services {
+		if s.Type == domain.ServiceTypePush && s.Config.Push != nil && s.Config.Push.Token == token {
+			return s, nil
+		}
+	}
+	return nil, errors.New("service not found")
+}
+
 func (r *InMemoryServiceRepository) GetAll(ctx context.Context) ([]*domain.Service, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -53,10 +66,26 @@ func (r *InMemoryServiceRepository) Update(ctx context.Context, service *domain.
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.services[service.ID]; !ok {
+	existing, ok := r.services[service.ID]
+	if !ok {
 		return errors.New("service not found")
 	}
-	r.services[service.ID] = service
+	updated := *service
+	updated.Status = existing.Status
+	r.services[service.ID] = &updated
+	return nil
+}
+
+func (r *InMemoryServiceRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ServiceStatus, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	service, ok := r.services[id]
+	if !ok {
+		return errors.New("service not found")
+	}
+	service.Status = status
+	service.UpdatedAt = at
 	return nil
 }
 
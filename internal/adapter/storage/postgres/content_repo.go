@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type PostgresContentSnapshotRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresContentSnapshotRepository(db *pgxpool.Pool) *PostgresContentSnapshotRepository {
+	return &PostgresContentSnapshotRepository{
+		db: db,
+	}
+}
+
+// Save inserts snapshot and prunes the service's history to keep entries.
+func (r *PostgresContentSnapshotRepository) Save(ctx context.Context, snapshot *domain.ContentSnapshot, keep int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save content snapshot: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insert := `
+		INSERT INTO content_snapshots (service_id, hash, content, captured_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.Exec(ctx, insert, snapshot.ServiceID, snapshot.Hash, snapshot.Content, snapshot.CapturedAt); err != nil {
+		return fmt.Errorf("failed to save content snapshot: %w", err)
+	}
+
+	prune := `
+		DELETE FROM content_snapshots
+		WHERE service_id = $1 AND id NOT IN (
+			SELECT id FROM content_snapshots
+			WHERE service_id = $1
+			ORDER BY captured_at DESC, id DESC
+			LIMIT $2
+		)
+	`
+	if _, err := tx.Exec(ctx, prune, snapshot.ServiceID, keep); err != nil {
+		return fmt.Errorf("failed to prune content snapshots: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresContentSnapshotRepository) GetLatest(ctx context.Context, serviceID uuid.UUID) (*domain.ContentSnapshot, error) {
+	query := `
+		SELECT hash, content, captured_at
+		FROM content_snapshots
+		WHERE service_id = $1
+		ORDER BY captured_at DESC, id DESC
+		LIMIT 1
+	`
+
+	snap := domain.ContentSnapshot{ServiceID: serviceID}
+	err := r.db.QueryRow(ctx, query, serviceID).Scan(&snap.Hash, &snap.Content, &snap.CapturedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get content snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// GetHistory lists snapshots without their content, which GetByHash returns.
+func (r *PostgresContentSnapshotRepository) GetHistory(ctx context.Context, serviceID uuid.UUID, limit int) ([]domain.ContentSnapshot, error) {
+	query := `
+		SELECT hash, captured_at
+		FROM content_snapshots
+		WHERE service_id = $1
+		ORDER BY captured_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, serviceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []domain.ContentSnapshot
+	for rows.Next() {
+		snap := domain.ContentSnapshot{ServiceID: serviceID}
+		if err := rows.Scan(&snap.Hash, &snap.CapturedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan content snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, rows.Err()
+}
+
+func (r *PostgresContentSnapshotRepository) GetByHash(ctx context.Context, serviceID uuid.UUID, hash string) (*domain.ContentSnapshot, error) {
+	query := `
+		SELECT hash, content, captured_at
+		FROM content_snapshots
+		WHERE service_id = $1 AND hash = $2
+		ORDER BY captured_at DESC, id DESC
+		LIMIT 1
+	`
+
+	snap := domain.ContentSnapshot{ServiceID: serviceID}
+	err := r.db.QueryRow(ctx, query, serviceID, hash).Scan(&snap.Hash, &snap.Content, &snap.CapturedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get content snapshot: %w", err)
+	}
+	return &snap, nil
+}
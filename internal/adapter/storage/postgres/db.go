@@ -72,6 +72,21 @@ func runMigrations(ctx context.Context, db *pgxpool.Pool) error {
 			checked_at TIMESTAMP WITH TIME ZONE NOT NULL
 		);`,
 		`CREATE INDEX IF NOT EXISTS idx_checks_service_id_checked_at ON checks(service_id, checked_at DESC);`,
+		`CREATE TABLE IF NOT EXISTS heartbeats (
+			service_id UUID PRIMARY KEY REFERENCES services(id) ON DELETE CASCADE,
+			received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			duration BIGINT NOT NULL DEFAULT 0,
+			message TEXT NOT NULL DEFAULT ''
+		);`,
+		`CREATE TABLE IF NOT EXISTS content_snapshots (
+			id BIGSERIAL PRIMARY KEY,
+			service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+			hash VARCHAR(64) NOT NULL,
+			content TEXT NOT NULL,
+			captured_at TIMESTAMP WITH TIME ZONE NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_content_snapshots_service ON content_snapshots(service_id, captured_at DESC);`,
 	}
 
 	for _, q := range queries {
@@ -92,6 +107,19 @@ func runMigrations(ctx context.Context, db *pgxpool.Pool) error {
 		return fmt.Errorf("failed to migrate slack_enabled: %w", err)
 	}
 
+	columnMigrations := []string{
+		`ALTER TABLE services ADD COLUMN IF NOT EXISTS config JSONB NOT NULL DEFAULT '{}'`,
+		`ALTER TABLE services ADD COLUMN IF NOT EXISTS timeout BIGINT NOT NULL DEFAULT 0`,
+		`ALTER TABLE checks ADD COLUMN IF NOT EXISTS details JSONB`,
+		`ALTER TABLE checks ADD COLUMN IF NOT EXISTS failure_kind VARCHAR(50)`,
+		`ALTER TABLE checks ADD COLUMN IF NOT EXISTS location VARCHAR(100)`,
+	}
+	for _, q := range columnMigrations {
+		if _, err := db.Exec(ctx, q); err != nil {
+			return fmt.Errorf("failed to migrate columns: %w", err)
+		}
+	}
+
 	slog.Info("Database schema verified")
 	return nil
 }
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type PostgresHeartbeatRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresHeartbeatRepository(db *pgxpool.Pool) *PostgresHeartbeatRepository {
+	return &PostgresHeartbeatRepository{
+		db: db,
+	}
+}
+
+// Save keeps only the latest heartbeat per service.
+func (r *PostgresHeartbeatRepository) Save(ctx context.Context, heartbeat *domain.Heartbeat) error {
+	query := `
+		INSERT INTO heartbeats (service_id, received_at, status, duration, message)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (service_id) DO UPDATE
+		SET received_at = EXCLUDED.received_at, status = EXCLUDED.status,
+			duration = EXCLUDED.duration, message = EXCLUDED.message
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		heartbeat.ServiceID,
+		heartbeat.ReceivedAt,
+		heartbeat.Status,
+		heartbeat.Duration.Nanoseconds(),
+		heartbeat.Message,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save heartbeat: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresHeartbeatRepository) GetLatest(ctx context.Context, serviceID uuid.UUID) (*domain.Heartbeat, error) {
+	query := `
+		SELECT received_at, status, duration, message
+		FROM heartbeats
+		WHERE service_id = $1
+	`
+
+	hb := domain.Heartbeat{ServiceID: serviceID}
+	var durationNs int64
+	err := r.db.QueryRow(ctx, query, serviceID).Scan(&hb.ReceivedAt, &hb.Status, &durationNs, &hb.Message)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get heartbeat: %w", err)
+	}
+	hb.Duration = time.Duration(durationNs)
+
+	return &hb, nil
+}
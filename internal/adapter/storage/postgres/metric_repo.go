@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/umutaraz/pulseguard/internal/core/domain"
 )
@@ -20,16 +22,70 @@ func NewPostgresMetricRepository(db *pgxpool.Pool) *PostgresMetricRepository {
 	}
 }
 
+// checkDetails holds the structured parts of a CheckResult stored in the details column.
+type checkDetails struct {
+	Status      domain.ServiceStatus  `json:"status,omitempty"` // Verdict of the checker itself
+	TLS         *domain.TLSInfo       `json:"tls,omitempty"`
+	Timings     *domain.HTTPTimings   `json:"timings,omitempty"`
+	Steps       []domain.StepResult   `json:"steps,omitempty"`
+	Output      string                `json:"output,omitempty"`
+	PerfData    []domain.PerfDatum    `json:"perfdata,omitempty"`
+	WS          *domain.WSTimings     `json:"ws,omitempty"`
+	RemoteIP    string                `json:"remote_ip,omitempty"`
+	Families    []domain.FamilyResult `json:"families,omitempty"`
+	ContentHash string                `json:"content_hash,omitempty"`
+	Security    *domain.SecurityAudit `json:"security,omitempty"`
+}
+
+func newCheckDetails(result *domain.CheckResult) checkDetails {
+	return checkDetails{
+		Status:      result.Status,
+		TLS:         result.TLS,
+		Timings:     result.Timings,
+		Steps:       result.Steps,
+		Output:      result.Output,
+		PerfData:    result.PerfData,
+		WS:          result.WS,
+		RemoteIP:    result.RemoteIP,
+		Families:    result.Families,
+		ContentHash: result.ContentHash,
+		Security:    result.Security,
+	}
+}
+
+func (d checkDetails) applyTo(result *domain.CheckResult) {
+	result.Status = d.Status
+	result.TLS = d.TLS
+	result.Timings = d.Timings
+	result.Steps = d.Steps
+	result.Output = d.Output
+	result.PerfData = d.PerfData
+	result.WS = d.WS
+	result.RemoteIP = d.RemoteIP
+	result.Families = d.Families
+	result.ContentHash = d.ContentHash
+	result.Security = d.Security
+}
+
+// marshal returns nil when there is nothing to store, keeping the column NULL.
+func (d checkDetails) marshal() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil || string(data) == "{}" {
+		return nil, err
+	}
+	return data, nil
+}
+
 func (r *PostgresMetricRepository) Save(ctx context.Context, result *domain.CheckResult) error {
 	query := `
-		INSERT INTO checks (id, service_id, checked_at, status_code, latency, success, error_message)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO checks (id, service_id, checked_at, status_code, latency, success, failure_kind, error_message, details, location)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 	// ... (Rest of logic is fine, latencyNs is passed as arg 4)
 
 	newID := uuid.New()
 	latencyNs := result.Latency.Nanoseconds()
-	
+
 	var errorMessage *string
 	if result.ErrorMessage != "" {
 		errorMessage = &result.ErrorMessage
@@ -38,15 +94,31 @@ func (r *PostgresMetricRepository) Save(ctx context.Context, result *domain.Chec
 	if result.StatusCode != 0 {
 		statusCode = &result.StatusCode
 	}
+	var failureKind *string
+	if result.FailureKind != "" {
+		kind := string(result.FailureKind)
+		failureKind = &kind
+	}
+	var location *string
+	if result.Location != "" {
+		location = &result.Location
+	}
+	detailsJSON, err := newCheckDetails(result).marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal check details: %w", err)
+	}
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		newID,
 		result.ServiceID,
 		result.CheckedAt,
 		statusCode,
 		latencyNs,
 		result.Success,
+		failureKind,
 		errorMessage,
+		detailsJSON,
+		location,
 	)
 
 	if err != nil {
@@ -59,7 +131,7 @@ func (r *PostgresMetricRepository) Save(ctx context.Context, result *domain.Chec
 // GetHistory Last N metrics for a service
 func (r *PostgresMetricRepository) GetHistory(ctx context.Context, serviceID uuid.UUID, limit int) ([]domain.CheckResult, error) {
 	query := `
-		SELECT checked_at, status_code, latency, success, error_message 
+		SELECT checked_at, status_code, latency, success, failure_kind, error_message, details, location
 		FROM checks 
 		WHERE service_id = $1 
 		ORDER BY checked_at DESC 
@@ -70,17 +142,42 @@ func (r *PostgresMetricRepository) GetHistory(ctx context.Context, serviceID uui
 	if err != nil {
 		return nil, fmt.Errorf("failed to query history: %w", err)
 	}
+	return scanChecks(rows, serviceID)
+}
+
+// GetLatestByLocation returns the most recent check from every location that
+// reported since the given time.
+func (r *PostgresMetricRepository) GetLatestByLocation(ctx context.Context, serviceID uuid.UUID, since time.Time) ([]domain.CheckResult, error) {
+	query := `
+		SELECT DISTINCT ON (COALESCE(location, ''))
+			checked_at, status_code, latency, success, failure_kind, error_message, details, location
+		FROM checks
+		WHERE service_id = $1 AND checked_at >= $2
+		ORDER BY COALESCE(location, ''), checked_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, serviceID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest checks by location: %w", err)
+	}
+	return scanChecks(rows, serviceID)
+}
+
+// scanChecks reads rows selected as checked_at, status_code, latency,
+// success, failure_kind, error_message, details, location.
+func scanChecks(rows pgx.Rows, serviceID uuid.UUID) ([]domain.CheckResult, error) {
 	defer rows.Close()
 
 	var results []domain.CheckResult
 	for rows.Next() {
 		var r domain.CheckResult
 		r.ServiceID = serviceID
-		var errorMessage *string
+		var errorMessage, failureKind, location *string
 		var statusCode *int
 		var latencyNs int64
-		
-		if err := rows.Scan(&r.CheckedAt, &statusCode, &latencyNs, &r.Success, &errorMessage); err != nil {
+		var detailsJSON []byte
+
+		if err := rows.Scan(&r.CheckedAt, &statusCode, &latencyNs, &r.Success, &failureKind, &errorMessage, &detailsJSON, &location); err != nil {
 			return nil, err
 		}
 
@@ -92,11 +189,24 @@ func (r *PostgresMetricRepository) GetHistory(ctx context.Context, serviceID uui
 		if statusCode != nil {
 			r.StatusCode = *statusCode
 		}
+		if failureKind != nil {
+			r.FailureKind = domain.FailureKind(*failureKind)
+		}
+		if location != nil {
+			r.Location = *location
+		}
+		if len(detailsJSON) > 0 {
+			var details checkDetails
+			if err := json.Unmarshal(detailsJSON, &details); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal check details: %w", err)
+			}
+			details.applyTo(&r)
+		}
 
 		results = append(results, r)
 	}
 
-	return results, nil
+	return results, rows.Err()
 }
 
 func (r *PostgresMetricRepository) GetStats(ctx context.Context, serviceID uuid.UUID, since time.Time) (*domain.ServiceStats, error) {
@@ -124,6 +234,11 @@ func (r *PostgresMetricRepository) GetStats(ctx context.Context, serviceID uuid.
 		Since:        since,
 	}
 
+	stats.FailuresByKind, err = r.failuresByKind(ctx, serviceID, since)
+	if err != nil {
+		return nil, err
+	}
+
 	if total > 0 {
 		successCount := total - failed
 		stats.UptimePercentage = (float64(successCount) / float64(total)) * 100
@@ -133,3 +248,31 @@ func (r *PostgresMetricRepository) GetStats(ctx context.Context, serviceID uuid.
 
 	return stats, nil
 }
+
+// failuresByKind counts failed checks per failure kind. Checks recorded before
+// failure kinds existed are counted as UNKNOWN.
+func (r *PostgresMetricRepository) failuresByKind(ctx context.Context, serviceID uuid.UUID, since time.Time) (map[domain.FailureKind]int, error) {
+	query := `
+		SELECT COALESCE(failure_kind, 'UNKNOWN'), COUNT(*)
+		FROM checks
+		WHERE service_id = $1 AND checked_at >= $2 AND success = false
+		GROUP BY 1
+	`
+
+	rows, err := r.db.Query(ctx, query, serviceID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failures by kind: %w", err)
+	}
+	defer rows.Close()
+
+	byKind := make(map[domain.FailureKind]int)
+	for rows.Next() {
+		var kind string
+		var count int
+		if err := rows.Scan(&kind, &count); err != nil {
+			return nil, err
+		}
+		byKind[domain.FailureKind(kind)] = count
+	}
+	return byKind, rows.Err()
+}
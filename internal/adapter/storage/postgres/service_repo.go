@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,77 +23,107 @@ func NewPostgresServiceRepository(db *pgxpool.Pool) *PostgresServiceRepository {
 	}
 }
 
-func (r *PostgresServiceRepository) Create(ctx context.Context, service *domain.Service) error {
-	query := `
-		INSERT INTO services (id, name, url, interval, type, thresholds, status, slack_enabled, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`
-	
+const insertServiceQuery = `
+	INSERT INTO services (id, name, url, interval, timeout, type, thresholds, config, status, slack_enabled, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+`
+
+// insertServiceArgs returns the arguments of insertServiceQuery, in order.
+func insertServiceArgs(service *domain.Service) ([]any, error) {
 	thresholdsJSON, _ := json.Marshal(service.Thresholds)
+	configJSON, err := json.Marshal(service.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
 
-	_, err := r.db.Exec(ctx, query,
+	return []any{
 		service.ID,
 		service.Name,
 		service.URL,
 		service.Interval,
+		service.Timeout,
 		service.Type,
 		thresholdsJSON,
+		configJSON,
 		service.Status,
 		service.SlackEnabled,
 		service.CreatedAt,
 		service.UpdatedAt,
-	)
+	}, nil
+}
 
+func (r *PostgresServiceRepository) Create(ctx context.Context, service *domain.Service) error {
+	args, err := insertServiceArgs(service)
 	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, insertServiceQuery, args...); err != nil {
 		return fmt.Errorf("failed to create service: %w", err)
 	}
 
 	return nil
 }
 
+// serviceColumns is the column list scanned by scanService, in order.
+const serviceColumns = `id, name, url, interval, timeout, type, thresholds, config, status, slack_enabled, created_at, updated_at`
 
-func (r *PostgresServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
-	query := `
-		SELECT id, name, url, interval, type, thresholds, status, slack_enabled, created_at, updated_at
-		FROM services
-		WHERE id = $1
-	`
-
+func scanService(row pgx.Row) (*domain.Service, error) {
 	var service domain.Service
-	var thresholdsJSON []byte
+	var thresholdsJSON, configJSON []byte
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	if err := row.Scan(
 		&service.ID,
 		&service.Name,
 		&service.URL,
 		&service.Interval,
+		&service.Timeout,
 		&service.Type,
 		&thresholdsJSON,
+		&configJSON,
 		&service.Status,
 		&service.SlackEnabled,
 		&service.CreatedAt,
 		&service.UpdatedAt,
-	)
+	); err != nil {
+		return nil, err
+	}
 
+	if err := json.Unmarshal(thresholdsJSON, &service.Thresholds); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal thresholds: %w", err)
+	}
+	if len(configJSON) > 0 {
+		if err := json.Unmarshal(configJSON, &service.Config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	return &service, nil
+}
+
+func (r *PostgresServiceRepository) getOne(ctx context.Context, where string, arg any) (*domain.Service, error) {
+	query := `SELECT ` + serviceColumns + ` FROM services WHERE ` + where
+
+	service, err := scanService(r.db.QueryRow(ctx, query, arg))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errors.New("service not found")
 		}
 		return nil, fmt.Errorf("failed to get service: %w", err)
 	}
+	return service, nil
+}
 
-	if err := json.Unmarshal(thresholdsJSON, &service.Thresholds); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal thresholds: %w", err)
-	}
+func (r *PostgresServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	return r.getOne(ctx, `id = $1`, id)
+}
 
-	return &service, nil
+func (r *PostgresServiceRepository) GetByPushToken(ctx context.Context, token string) (*domain.Service, error) {
+	return r.getOne(ctx, `type = 'PUSH' AND config->'push'->>'token' = $1`, token)
 }
 
 func (r *PostgresServiceRepository) GetAll(ctx context.Context) ([]*domain.Service, error) {
-	query := `
-		SELECT id, name, url, interval, type, thresholds, status, slack_enabled, created_at, updated_at
-		FROM services
-	`
+	query := `SELECT ` + serviceColumns + ` FROM services`
 
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
@@ -102,44 +133,73 @@ func (r *PostgresServiceRepository) GetAll(ctx context.Context) ([]*domain.Servi
 
 	var services []*domain.Service
 	for rows.Next() {
-		var service domain.Service
-		var thresholdsJSON []byte
-
-		if err := rows.Scan(
-			&service.ID,
-			&service.Name,
-			&service.URL,
-			&service.Interval,
-			&service.Type,
-			&thresholdsJSON,
-			&service.Status,
-			&service.SlackEnabled,
-			&service.CreatedAt,
-			&service.UpdatedAt,
-		); err != nil {
+		service, err := scanService(rows)
+		if err != nil {
 			return nil, err
 		}
+		services = append(services, service)
+	}
 
-		if err := json.Unmarshal(thresholdsJSON, &service.Thresholds); err != nil {
-			// Log error but continue
-		}
+	return services, nil
+}
 
-		services = append(services, &service)
+// updateServiceQuery rewrites the definition of a service. Status is owned
+// by UpdateStatus and left alone.
+const updateServiceQuery = `
+	UPDATE services
+	SET name = $1, url = $2, interval = $3, timeout = $4, type = $5, thresholds = $6,
+		config = $7, slack_enabled = $8, updated_at = $9
+	WHERE id = $10
+`
+
+// updateServiceArgs returns the arguments of updateServiceQuery, in order.
+func updateServiceArgs(service *domain.Service) ([]any, error) {
+	thresholdsJSON, _ := json.Marshal(service.Thresholds)
+	configJSON, err := json.Marshal(service.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	return services, nil
+	return []any{
+		service.Name,
+		service.URL,
+		service.Interval,
+		service.Timeout,
+		service.Type,
+		thresholdsJSON,
+		configJSON,
+		service.SlackEnabled,
+		service.UpdatedAt,
+		service.ID,
+	}, nil
 }
 
 func (r *PostgresServiceRepository) Update(ctx context.Context, service *domain.Service) error {
+	args, err := updateServiceArgs(service)
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Exec(ctx, updateServiceQuery, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update service: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("service not found")
+	}
+	return nil
+}
+
+func (r *PostgresServiceRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ServiceStatus, at time.Time) error {
 	query := `
 		UPDATE services 
 		SET status = $1, updated_at = $2 
 		WHERE id = $3
 	`
 
-	_, err := r.db.Exec(ctx, query, service.Status, service.UpdatedAt, service.ID)
+	_, err := r.db.Exec(ctx, query, status, at, id)
 	if err != nil {
-		return fmt.Errorf("failed to update service: %w", err)
+		return fmt.Errorf("failed to update service status: %w", err)
 	}
 	return nil
 }
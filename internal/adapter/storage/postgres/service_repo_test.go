@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// placeholders returns the highest $N in query; pgx expects exactly that
+// many arguments.
+func placeholders(query string) int {
+	highest := 0
+	for _, m := range placeholder.FindAllStringSubmatch(query, -1) {
+		if n, _ := strconv.Atoi(m[1]); n > highest {
+			highest = n
+		}
+	}
+	return highest
+}
+
+func TestServiceQueryArguments(t *testing.T) {
+	service := domain.NewService("api", "https://example.com", domain.ServiceTypeHTTP, time.Minute, false)
+
+	tests := []struct {
+		name  string
+		query string
+		args  func(*domain.Service) ([]any, error)
+	}{
+		{name: "insert", query: insertServiceQuery, args: insertServiceArgs},
+		{name: "update", query: updateServiceQuery, args: updateServiceArgs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := tt.args(service)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := placeholders(tt.query); len(args) != want {
+				t.Errorf("%d arguments for %d placeholders", len(args), want)
+			}
+		})
+	}
+}
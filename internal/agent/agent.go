@@ -0,0 +1,218 @@
+// Package agent runs PulseGuard as a probe: it checks the services assigned
+// to its location with the regular checkers and reports the results to the
+// central server over HTTP.
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/monitor/scheduler"
+)
+
+const (
+	locationHeader = "X-PulseGuard-Location"
+
+	flushInterval       = time.Second
+	maxPending          = 10000 // Results buffered while the server is unreachable; the oldest are dropped first
+	requestTimeout      = 10 * time.Second
+	defaultPollInterval = 30 * time.Second
+)
+
+// Agent keeps its engine in sync with the checks assigned by the server and
+// forwards every result in batches.
+type Agent struct {
+	cfg    config.AgentConfig
+	client *http.Client
+	engine *scheduler.MonitoringEngine
+
+	// Services being checked, as last synced
+	assigned map[uuid.UUID]*domain.Service
+
+	mu      sync.Mutex
+	pending []domain.CheckResult
+}
+
+func NewAgent(cfg config.AgentConfig, engine *scheduler.MonitoringEngine) (*Agent, error) {
+	if cfg.Token == "" || cfg.ServerURL == "" || cfg.Location == "" {
+		return nil, errors.New("agent requires agent.token, agent.server_url and agent.location")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	cfg.ServerURL = strings.TrimRight(cfg.ServerURL, "/")
+
+	a := &Agent{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: requestTimeout},
+		engine:   engine,
+		assigned: make(map[uuid.UUID]*domain.Service),
+	}
+	engine.SetResultHandler(a.enqueue)
+	return a, nil
+}
+
+// Run registers with the server and keeps checking and reporting until ctx
+// is done. Buffered results are flushed one last time before it returns.
+func (a *Agent) Run(ctx context.Context) error {
+	if err := a.do(ctx, http.MethodPost, "/api/v1/agents/register", nil, nil); err != nil {
+		return fmt.Errorf("failed to register with %s: %w", a.cfg.ServerURL, err)
+	}
+	slog.Info("Agent: Registered", "server", a.cfg.ServerURL, "location", a.cfg.Location)
+
+	a.sync(ctx)
+
+	poll := time.NewTicker(a.cfg.PollInterval)
+	defer poll.Stop()
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.engine.Shutdown()
+			final, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			a.flush(final)
+			cancel()
+			return nil
+		case <-poll.C:
+			a.sync(ctx)
+		case <-flush.C:
+			a.flush(ctx)
+		}
+	}
+}
+
+// sync converges the engine on the services currently assigned to the
+// location. Failures keep the current checks running.
+func (a *Agent) sync(ctx context.Context) {
+	var services []*domain.Service
+	if err := a.do(ctx, http.MethodGet, "/api/v1/agents/checks", nil, &services); err != nil {
+		slog.Error("Agent: Failed to fetch assigned checks", "error", err)
+		return
+	}
+
+	current := make(map[uuid.UUID]bool, len(services))
+	for _, s := range services {
+		current[s.ID] = true
+		if prev, ok := a.assigned[s.ID]; !ok {
+			a.engine.StartMonitorForService(s)
+		} else if !prev.SameDefinition(s) {
+			a.engine.UpdateMonitorForService(s)
+		}
+		a.assigned[s.ID] = s
+	}
+	for id := range a.assigned {
+		if !current[id] {
+			a.engine.StopMonitorForService(id)
+			delete(a.assigned, id)
+		}
+	}
+}
+
+func (a *Agent) enqueue(result domain.CheckResult) {
+	result.Location = a.cfg.Location
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.pending) >= maxPending {
+		slog.Warn("Agent: Result buffer full, dropping oldest result", "service_id", a.pending[0].ServiceID)
+		a.pending = a.pending[1:]
+	}
+	a.pending = append(a.pending, result)
+}
+
+// flush sends the buffered results. A batch the server could not be reached
+// for is kept for the next flush; one it rejected is dropped.
+func (a *Agent) flush(ctx context.Context) {
+	a.mu.Lock()
+	batch := a.pending
+	a.pending = nil
+	a.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	err := a.do(ctx, http.MethodPost, "/api/v1/agents/results", batch, nil)
+	if err == nil {
+		return
+	}
+	var rejected *rejectedError
+	if errors.As(err, &rejected) {
+		slog.Error("Agent: Server rejected results", "count", len(batch), "error", err)
+		return
+	}
+
+	slog.Warn("Agent: Failed to submit results, will retry", "count", len(batch), "error", err)
+	a.mu.Lock()
+	a.pending = append(batch, a.pending...)
+	if excess := len(a.pending) - maxPending; excess > 0 {
+		a.pending = a.pending[excess:]
+	}
+	a.mu.Unlock()
+}
+
+// rejectedError is a 4xx answer; retrying the same request won't help.
+type rejectedError struct {
+	status  int
+	message string
+}
+
+func (e *rejectedError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.status, e.message)
+}
+
+// do sends an authenticated request to the server, encoding body and
+// decoding the response into out when they are set.
+func (a *Agent) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.cfg.ServerURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.cfg.Token)
+	req.Header.Set(locationHeader, a.cfg.Location)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if resp.StatusCode < 500 {
+			return &rejectedError{status: resp.StatusCode, message: strings.TrimSpace(string(message))}
+		}
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
 )
 
 type Config struct {
@@ -14,12 +15,18 @@ type Config struct {
 	Postgres     PostgresConfig     `mapstructure:"postgres"`
 	Redis        RedisConfig        `mapstructure:"redis"`
 	Notification NotificationConfig `mapstructure:"notification"`
+	Exec         ExecConfig         `mapstructure:"exec"`
+	Security     SecurityConfig     `mapstructure:"security_audit"`
+	Scheduler    SchedulerConfig    `mapstructure:"scheduler"`
+	Cluster      ClusterConfig      `mapstructure:"cluster"`
+	Agent        AgentConfig        `mapstructure:"agent"`
 }
 
 type AppConfig struct {
 	Name        string `mapstructure:"name"`
 	Environment string `mapstructure:"environment"`
 	LogLevel    string `mapstructure:"log_level"`
+	Location    string `mapstructure:"location"` // Location recorded on the server's own checks
 }
 
 type ServerConfig struct {
@@ -47,6 +54,53 @@ type NotificationConfig struct {
 	SlackWebhookURL string `mapstructure:"slack_webhook_url"`
 }
 
+// ExecConfig sandboxes EXEC checks. They are disabled while CommandDir is empty.
+type ExecConfig struct {
+	CommandDir     string   `mapstructure:"command_dir"`      // Only commands inside this directory may run
+	MaxOutputBytes int      `mapstructure:"max_output_bytes"` // Plugin output beyond this is discarded
+	PassEnv        []string `mapstructure:"pass_env"`         // Variables inherited from PulseGuard's environment
+}
+
+// SchedulerConfig sizes the worker pool that runs checks and controls how
+// checks are spread over time.
+type SchedulerConfig struct {
+	Workers     int           `mapstructure:"workers"`      // Checks running at once
+	QueueSize   int           `mapstructure:"queue_size"`   // Due checks waiting for a worker; more are skipped
+	Jitter      time.Duration `mapstructure:"jitter"`       // Random delay of up to this much added to every check
+	StartupRate float64       `mapstructure:"startup_rate"` // First checks started per second, 0 for no limit
+	MaxPerHost  int           `mapstructure:"max_per_host"` // Concurrent checks per target host, 0 for no limit
+}
+
+// ClusterConfig applies when several replicas share a Redis instance. Each
+// node heartbeats a key in Redis and checks only its share of the services.
+type ClusterConfig struct {
+	NodeID            string        `mapstructure:"node_id"`            // Defaults to the hostname plus a random suffix
+	HeartbeatTTL      time.Duration `mapstructure:"heartbeat_ttl"`      // A node missing heartbeats this long is dropped
+	RebalanceInterval time.Duration `mapstructure:"rebalance_interval"` // How often ownership is recomputed
+}
+
+// AgentConfig connects probe agents to the server. The server accepts agents
+// only when Token is set; an agent started with `pulseguard agent` uses all
+// fields to register and report.
+type AgentConfig struct {
+	Token        string        `mapstructure:"token"`         // Shared secret agents authenticate with
+	ServerURL    string        `mapstructure:"server_url"`    // Base URL of the server, e.g. https://pulse.example.com
+	Location     string        `mapstructure:"location"`      // Location the agent reports from
+	PollInterval time.Duration `mapstructure:"poll_interval"` // How often assigned checks are refreshed
+}
+
+// SecurityConfig is the global policy of the security header audit, used by
+// services that enable the audit without a policy of their own.
+type SecurityConfig struct {
+	HSTS               bool  `mapstructure:"hsts"`
+	HSTSMinMaxAge      int64 `mapstructure:"hsts_min_max_age"` // Seconds
+	CSP                bool  `mapstructure:"csp"`
+	ContentTypeOptions bool  `mapstructure:"content_type_options"`
+	FrameOptions       bool  `mapstructure:"frame_options"`
+	SecureCookies      bool  `mapstructure:"secure_cookies"`
+	HTTPOnlyCookies    bool  `mapstructure:"httponly_cookies"`
+}
+
 // LoadConfig reads configuration from file or environment variables.
 func LoadConfig() (*Config, error) {
 	v := viper.New()
@@ -55,6 +109,7 @@ func LoadConfig() (*Config, error) {
 	v.SetDefault("app.name", "PulseGuard")
 	v.SetDefault("app.environment", "dev")
 	v.SetDefault("app.log_level", "info")
+	v.SetDefault("app.location", domain.DefaultLocation)
 	v.SetDefault("server.port", "8080")
 	v.SetDefault("server.read_timeout", "10s")
 	v.SetDefault("server.write_timeout", "10s")
@@ -68,6 +123,25 @@ func LoadConfig() (*Config, error) {
 
 	v.SetDefault("redis.addr", "localhost:6379")
 
+	v.SetDefault("exec.max_output_bytes", 8192)
+
+	v.SetDefault("scheduler.workers", 100)
+	v.SetDefault("scheduler.queue_size", 1000)
+	v.SetDefault("scheduler.startup_rate", 50)
+
+	v.SetDefault("cluster.heartbeat_ttl", "15s")
+	v.SetDefault("cluster.rebalance_interval", "10s")
+
+	v.SetDefault("agent.poll_interval", "30s")
+
+	v.SetDefault("security_audit.hsts", true)
+	v.SetDefault("security_audit.hsts_min_max_age", 15552000)
+	v.SetDefault("security_audit.csp", true)
+	v.SetDefault("security_audit.content_type_options", true)
+	v.SetDefault("security_audit.frame_options", true)
+	v.SetDefault("security_audit.secure_cookies", true)
+	v.SetDefault("security_audit.httponly_cookies", true)
+
 	// 2. Config File (Support local dev)
 	v.AddConfigPath(".") // Current directory
 	v.AddConfigPath("./configs")
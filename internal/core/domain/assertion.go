@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Assertion types evaluated against an HTTP response.
+const (
+	AssertBodyContains    = "body_contains"
+	AssertBodyNotContains = "body_not_contains"
+	AssertBodyRegex       = "body_regex"
+	AssertJSONPath        = "json_path" // Target is a path such as $.status.healthy or items[0].id
+	AssertHeader          = "header"    // Target is the header name
+)
+
+// Assertion comparison operators for json_path and header assertions.
+const (
+	OpEquals      = "eq"
+	OpNotEquals   = "ne"
+	OpLess        = "lt"
+	OpLessOrEq    = "lte"
+	OpGreater     = "gt"
+	OpGreaterOrEq = "gte"
+	OpContains    = "contains"
+	OpExists      = "exists"
+)
+
+// Assertion is a single rule a response must satisfy for the check to succeed.
+type Assertion struct {
+	Type     string `json:"type"`
+	Target   string `json:"target,omitempty"`
+	Operator string `json:"operator,omitempty"` // Defaults to eq, or exists for headers without a value
+	Value    string `json:"value,omitempty"`
+}
+
+func (a Assertion) GetOperator() string {
+	if a.Operator != "" {
+		return a.Operator
+	}
+	if a.Type == AssertHeader && a.Value == "" {
+		return OpExists
+	}
+	return OpEquals
+}
+
+func (a Assertion) Validate() error {
+	switch a.Type {
+	case AssertBodyContains, AssertBodyNotContains:
+		if a.Value == "" {
+			return fmt.Errorf("%s assertion requires a value", a.Type)
+		}
+	case AssertBodyRegex:
+		if _, err := regexp.Compile(a.Value); err != nil {
+			return fmt.Errorf("invalid body_regex: %w", err)
+		}
+	case AssertJSONPath, AssertHeader:
+		if a.Target == "" {
+			return fmt.Errorf("%s assertion requires a target", a.Type)
+		}
+		switch a.GetOperator() {
+		case OpEquals, OpNotEquals, OpLess, OpLessOrEq, OpGreater, OpGreaterOrEq, OpContains, OpExists:
+		default:
+			return fmt.Errorf("unsupported assertion operator %q", a.Operator)
+		}
+	case "":
+		return errors.New("assertion type is required")
+	default:
+		return fmt.Errorf("unsupported assertion type %q", a.Type)
+	}
+	return nil
+}
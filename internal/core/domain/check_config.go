@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckConfig holds the type-specific settings of a service. Only the block
+// matching Service.Type is read by the checker; the rest stay nil. IPFamily
+// applies to every check that opens a connection; Locations and Quorum to
+// every service checked by probe agents.
+type CheckConfig struct {
+	IPFamily  string   `json:"ip_family,omitempty"` // ipv4, ipv6 or dual; empty lets the dialer choose
+	Locations []string `json:"locations,omitempty"` // Agent locations checking the service besides the server
+	Quorum    int      `json:"quorum,omitempty"`    // Failing locations needed for DOWN, defaults to a majority
+
+	HTTP       *HTTPConfig       `json:"http,omitempty"`
+	TCP        *TCPConfig        `json:"tcp,omitempty"`
+	DNS        *DNSConfig        `json:"dns,omitempty"`
+	TLS        *TLSConfig        `json:"tls,omitempty"`
+	Push       *PushConfig       `json:"push,omitempty"`
+	Scenario   *ScenarioConfig   `json:"scenario,omitempty"`
+	Exec       *ExecConfig       `json:"exec,omitempty"`
+	GRPC       *GRPCConfig       `json:"grpc,omitempty"`
+	Postgres   *PostgresConfig   `json:"postgres,omitempty"`
+	Redis      *RedisConfig      `json:"redis,omitempty"`
+	Prometheus *PrometheusConfig `json:"prometheus,omitempty"`
+	WS         *WSConfig         `json:"ws,omitempty"`
+}
+
+// HTTP auth schemes.
+const (
+	AuthBasic  = "basic"
+	AuthBearer = "bearer"
+)
+
+// HTTPConfig describes how an HTTP check is performed and judged.
+type HTTPConfig struct {
+	Method          string               `json:"method,omitempty"` // Defaults to GET
+	Headers         map[string]string    `json:"headers,omitempty"`
+	Body            string               `json:"body,omitempty"`
+	Auth            *HTTPAuth            `json:"auth,omitempty"`
+	AcceptedStatus  []string             `json:"accepted_status,omitempty"`  // Codes or ranges such as "200-299", "401"; defaults to 200-399
+	FollowRedirects *bool                `json:"follow_redirects,omitempty"` // Defaults to true
+	Assertions      []Assertion          `json:"assertions,omitempty"`
+	MaxResponseSize int64                `json:"max_response_size,omitempty"` // Bytes, 0 means the default 1 MiB cap
+	Transport       *TransportConfig     `json:"transport,omitempty"`
+	ContentWatch    *ContentWatchConfig  `json:"content_watch,omitempty"`
+	SecurityAudit   *SecurityAuditConfig `json:"security_audit,omitempty"`
+}
+
+type HTTPAuth struct {
+	Type     string `json:"type"` // basic or bearer
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+func (c *HTTPConfig) GetMethod() string {
+	if c == nil || c.Method == "" {
+		return "GET"
+	}
+	return strings.ToUpper(c.Method)
+}
+
+func (c *HTTPConfig) ShouldFollowRedirects() bool {
+	return c == nil || c.FollowRedirects == nil || *c.FollowRedirects
+}
+
+// HasAcceptedStatus reports whether accepted_status overrides the default
+// success range.
+func (c *HTTPConfig) HasAcceptedStatus() bool {
+	return c != nil && len(c.AcceptedStatus) > 0
+}
+
+// AcceptsStatus reports whether code counts as a successful response.
+func (c *HTTPConfig) AcceptsStatus(code int) bool {
+	if !c.HasAcceptedStatus() {
+		return code >= 200 && code < 400
+	}
+	for _, spec := range c.AcceptedStatus {
+		lo, hi, err := parseStatusRange(spec)
+		if err == nil && code >= lo && code <= hi {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStatusRange parses "404" or "200-299" into an inclusive range.
+func parseStatusRange(spec string) (int, int, error) {
+	spec = strings.TrimSpace(spec)
+	loStr, hiStr, isRange := strings.Cut(spec, "-")
+	lo, err := strconv.Atoi(strings.TrimSpace(loStr))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid status code %q", spec)
+	}
+	hi := lo
+	if isRange {
+		if hi, err = strconv.Atoi(strings.TrimSpace(hiStr)); err != nil {
+			return 0, 0, fmt.Errorf("invalid status range %q", spec)
+		}
+	}
+	if lo < 100 || hi > 599 || lo > hi {
+		return 0, 0, fmt.Errorf("invalid status range %q", spec)
+	}
+	return lo, hi, nil
+}
+
+func validateHTTP(rawURL string, cfg *HTTPConfig) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("invalid URL")
+	}
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MaxResponseSize < 0 {
+		return errors.New("max_response_size must not be negative")
+	}
+	switch cfg.GetMethod() {
+	case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS":
+	default:
+		return fmt.Errorf("unsupported HTTP method %q", cfg.Method)
+	}
+	for _, spec := range cfg.AcceptedStatus {
+		if _, _, err := parseStatusRange(spec); err != nil {
+			return err
+		}
+	}
+	if auth := cfg.Auth; auth != nil {
+		switch auth.Type {
+		case AuthBasic:
+			if auth.Username == "" {
+				return errors.New("basic auth requires a username")
+			}
+		case AuthBearer:
+			if auth.Token == "" {
+				return errors.New("bearer auth requires a token")
+			}
+		default:
+			return fmt.Errorf("unsupported auth type %q", auth.Type)
+		}
+	}
+	if err := validateTransport(cfg.Transport); err != nil {
+		return err
+	}
+	if err := validateContentWatch(cfg.ContentWatch); err != nil {
+		return err
+	}
+	if err := validateSecurityAudit(cfg.SecurityAudit); err != nil {
+		return err
+	}
+	for i, a := range cfg.Assertions {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("assertion %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// TCPConfig describes a TCP connectivity check against Service.URL ("host:port").
+type TCPConfig struct {
+	Send        string `json:"send,omitempty"`         // Optional payload written right after connecting
+	Expect      string `json:"expect,omitempty"`       // Substring the response banner must contain
+	ExpectRegex string `json:"expect_regex,omitempty"` // Regex the response banner must match
+}
+
+// ExpectsBanner reports whether the check needs to read a response.
+func (c *TCPConfig) ExpectsBanner() bool {
+	return c != nil && (c.Expect != "" || c.ExpectRegex != "")
+}
+
+// DNS record types supported by DNS checks.
+const (
+	DNSRecordA     = "A"
+	DNSRecordAAAA  = "AAAA"
+	DNSRecordCNAME = "CNAME"
+	DNSRecordMX    = "MX"
+	DNSRecordTXT   = "TXT"
+	DNSRecordSRV   = "SRV"
+)
+
+// DNS answer matching modes.
+const (
+	DNSMatchContains = "contains" // Every expected value must be among the answers
+	DNSMatchEquals   = "equals"   // Answers must be exactly the expected set
+)
+
+// DNSConfig describes a DNS resolution check for the name in Service.URL.
+type DNSConfig struct {
+	Resolver   string   `json:"resolver,omitempty"`    // "host:port" of the nameserver, system resolver if empty
+	RecordType string   `json:"record_type,omitempty"` // Defaults to A
+	Expected   []string `json:"expected,omitempty"`    // MX answers are hosts, SRV answers are "target:port"
+	Match      string   `json:"match,omitempty"`       // Defaults to contains
+}
+
+func (c *DNSConfig) GetRecordType() string {
+	if c == nil || c.RecordType == "" {
+		return DNSRecordA
+	}
+	return strings.ToUpper(c.RecordType)
+}
+
+func (c *DNSConfig) GetMatch() string {
+	if c == nil || c.Match == "" {
+		return DNSMatchContains
+	}
+	return c.Match
+}
+
+func validateDNS(name string, cfg *DNSConfig) error {
+	if name == "" || strings.ContainsAny(name, " /:") {
+		return errors.New("invalid DNS name")
+	}
+	switch cfg.GetRecordType() {
+	case DNSRecordA, DNSRecordAAAA, DNSRecordCNAME, DNSRecordMX, DNSRecordTXT, DNSRecordSRV:
+	default:
+		return fmt.Errorf("unsupported DNS record type %q", cfg.RecordType)
+	}
+	switch cfg.GetMatch() {
+	case DNSMatchContains, DNSMatchEquals:
+	default:
+		return fmt.Errorf("unsupported DNS match mode %q", cfg.Match)
+	}
+	if cfg != nil && cfg.Resolver != "" {
+		if _, _, err := net.SplitHostPort(cfg.Resolver); err != nil {
+			return errors.New("invalid DNS resolver, expected host:port")
+		}
+	}
+	return nil
+}
+
+// TLSConfig describes a TLS handshake check against Service.URL ("host:port", port defaults to 443).
+type TLSConfig struct {
+	ServerName string `json:"server_name,omitempty"` // SNI and hostname to verify, defaults to the target host
+}
+
+func validateTLS(target string) error {
+	host := target
+	if h, _, err := net.SplitHostPort(target); err == nil {
+		host = h
+	}
+	if host == "" || strings.ContainsAny(host, " /") {
+		return errors.New("invalid TLS target, expected host[:port]")
+	}
+	return nil
+}
+
+func validateTCP(target string, cfg *TCPConfig) error {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil || host == "" || port == "" {
+		return errors.New("invalid TCP target, expected host:port")
+	}
+	if cfg != nil && cfg.ExpectRegex != "" {
+		if _, err := regexp.Compile(cfg.ExpectRegex); err != nil {
+			return fmt.Errorf("invalid expect_regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// PushConfig describes a heartbeat monitor. Instead of being polled, the
+// monitored job calls the heartbeat endpoint for Token after each run.
+type PushConfig struct {
+	Token        string `json:"token"`                   // Issued by the server, unique per service
+	GraceSeconds int    `json:"grace_seconds,omitempty"` // Extra slack on top of the interval
+}
+
+// Grace returns the tolerated delay past the interval before the service is considered down.
+func (c *PushConfig) Grace() time.Duration {
+	if c == nil || c.GraceSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.GraceSeconds) * time.Second
+}
+
+// NewPushToken returns a random, URL-safe heartbeat token.
+func NewPushToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func validatePush(target string, cfg *PushConfig) error {
+	if target != "" {
+		return errors.New("push services have no URL")
+	}
+	if cfg == nil || len(cfg.Token) < 16 {
+		return errors.New("push token must be at least 16 characters")
+	}
+	if cfg.GraceSeconds < 0 {
+		return errors.New("grace_seconds must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Snapshots kept per service when ContentWatchConfig.Keep is unset, and the
+// most it may be set to. Each snapshot holds up to a full response body.
+const (
+	DefaultContentSnapshots = 10
+	MaxContentSnapshots     = 100
+)
+
+// ContentWatchConfig enables change detection on the response body. The body
+// is reduced to the value at JSONPath, or has every Strip match removed, and
+// then hashed; a hash that differs from the previous snapshot raises a
+// ContentChange.
+type ContentWatchConfig struct {
+	JSONPath string   `json:"json_path,omitempty"` // Hash only this value, e.g. $.config
+	Strip    []string `json:"strip,omitempty"`     // Regexes of volatile regions such as timestamps or CSRF tokens
+	Keep     int      `json:"keep,omitempty"`      // Snapshots kept per service, defaults to DefaultContentSnapshots
+}
+
+func (c *ContentWatchConfig) GetKeep() int {
+	if c == nil || c.Keep <= 0 {
+		return DefaultContentSnapshots
+	}
+	return c.Keep
+}
+
+// ContentSnapshot is a stored version of a watched response.
+type ContentSnapshot struct {
+	ServiceID  uuid.UUID `json:"service_id"`
+	Hash       string    `json:"hash"`
+	Content    string    `json:"content,omitempty"` // Normalized body the hash was taken over, left out of listings
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// ContentChange is raised when a watched response no longer matches the
+// latest snapshot. It is independent of the service's up/down status.
+type ContentChange struct {
+	ServiceID    uuid.UUID `json:"service_id"`
+	ServiceName  string    `json:"service_name"`
+	PreviousHash string    `json:"previous_hash"`
+	Hash         string    `json:"hash"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
+
+func validateContentWatch(c *ContentWatchConfig) error {
+	if c == nil {
+		return nil
+	}
+	if c.Keep < 0 || c.Keep > MaxContentSnapshots {
+		return fmt.Errorf("content_watch keep must be between 0 and %d", MaxContentSnapshots)
+	}
+	if c.JSONPath != "" && len(c.Strip) > 0 {
+		return errors.New("content_watch json_path and strip are mutually exclusive")
+	}
+	for _, expr := range c.Strip {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid content_watch strip pattern %q: %w", expr, err)
+		}
+	}
+	return nil
+}
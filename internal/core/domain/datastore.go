@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// PostgresConfig describes a PostgreSQL check. Service.URL holds the DSN,
+// either as a postgres:// URL or as keyword/value pairs.
+type PostgresConfig struct {
+	Query    string `json:"query,omitempty"`    // Defaults to SELECT 1, always run read-only
+	Expected string `json:"expected,omitempty"` // Expected scalar result, compared as text
+}
+
+// GetQuery returns the probe query, defaulting to SELECT 1.
+func (c *PostgresConfig) GetQuery() string {
+	if c == nil || c.Query == "" {
+		return "SELECT 1"
+	}
+	return c.Query
+}
+
+// RedisConfig describes a Redis check. Service.URL holds either "host:port"
+// or a redis:// / rediss:// URL.
+type RedisConfig struct {
+	Key      string `json:"key,omitempty"`      // GET this key instead of PING
+	Expected string `json:"expected,omitempty"` // Expected value of Key
+}
+
+var dsnPassword = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// redactDSN masks the password of a URL or keyword/value DSN.
+func redactDSN(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		if u, err := url.Parse(dsn); err == nil {
+			q := u.Query()
+			if q.Has("password") {
+				q.Set("password", RedactedSecret)
+				u.RawQuery = q.Encode()
+			}
+			return u.Redacted()
+		}
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+RedactedSecret)
+}
+
+func validatePostgres(dsn string) error {
+	if strings.TrimSpace(dsn) == "" {
+		return errors.New("postgres services require a DSN")
+	}
+	return nil
+}
+
+func validateRedis(target string) error {
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			return errors.New("invalid Redis URL, expected redis://host:port")
+		}
+		return nil
+	}
+	host, port, err := net.SplitHostPort(target)
+	if err != nil || host == "" || port == "" {
+		return errors.New("invalid Redis target, expected host:port")
+	}
+	return nil
+}
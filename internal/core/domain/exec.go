@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ExecConfig describes a local plugin run for an EXEC service. Service.URL holds
+// the command, resolved inside the command directory configured for the engine.
+type ExecConfig struct {
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"` // Added to the scrubbed environment, see ProtectedEnv
+}
+
+// protectedEnv lists variables that change which code a plugin, its shell or
+// its interpreter loads. Services may not set them.
+var protectedEnv = map[string]bool{
+	"PATH": true, "IFS": true, "ENV": true, "BASH_ENV": true, "SHELLOPTS": true, "BASHOPTS": true,
+	"CDPATH": true, "GLOBIGNORE": true, "PS4": true, "PROMPT_COMMAND": true,
+	"PYTHONPATH": true, "PYTHONSTARTUP": true, "PYTHONHOME": true,
+	"PERL5LIB": true, "PERL5OPT": true, "PERLLIB": true,
+	"RUBYLIB": true, "RUBYOPT": true, "NODE_OPTIONS": true, "NODE_PATH": true,
+	"GCONV_PATH": true, "LOCPATH": true, "HOSTALIASES": true, "RES_OPTIONS": true,
+}
+
+// ProtectedEnv reports whether a service may not set the environment variable
+// name: PATH, loader variables such as LD_PRELOAD or DYLD_INSERT_LIBRARIES,
+// exported bash functions and variables shells or interpreters execute.
+func ProtectedEnv(name string) bool {
+	upper := strings.ToUpper(name)
+	return protectedEnv[upper] ||
+		strings.HasPrefix(upper, "LD_") ||
+		strings.HasPrefix(upper, "DYLD_") ||
+		strings.HasPrefix(upper, "BASH_FUNC_")
+}
+
+// PerfDatum is a single Nagios performance data entry,
+// 'label'=value[UOM];[warn];[crit];[min];[max].
+type PerfDatum struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+	Warn  string  `json:"warn,omitempty"`
+	Crit  string  `json:"crit,omitempty"`
+	Min   string  `json:"min,omitempty"`
+	Max   string  `json:"max,omitempty"`
+}
+
+func validateExec(command string, cfg *ExecConfig) error {
+	if command == "" {
+		return errors.New("exec services require a command")
+	}
+	for _, part := range strings.Split(filepath.ToSlash(command), "/") {
+		if part == ".." {
+			return errors.New("command must not leave the command directory")
+		}
+	}
+	if cfg == nil {
+		return nil
+	}
+	for k := range cfg.Env {
+		if k == "" || strings.ContainsAny(k, "=\x00") {
+			return fmt.Errorf("invalid environment variable name %q", k)
+		}
+		if ProtectedEnv(k) {
+			return fmt.Errorf("environment variable %s may not be set by a service", k)
+		}
+	}
+	return nil
+}
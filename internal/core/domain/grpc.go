@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+// GRPCConfig describes a gRPC health check (grpc.health.v1.Health/Check)
+// against Service.URL ("host:port").
+type GRPCConfig struct {
+	Service    string            `json:"service,omitempty"`     // Health service name, empty checks the whole server
+	TLS        bool              `json:"tls,omitempty"`         // Plaintext unless set
+	ServerName string            `json:"server_name,omitempty"` // TLS server name, defaults to the target host
+	Metadata   map[string]string `json:"metadata,omitempty"`    // Sent with the request, e.g. authorization
+}
+
+func validateGRPC(target string, cfg *GRPCConfig) error {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil || host == "" || port == "" {
+		return errors.New("invalid gRPC target, expected host:port")
+	}
+	if cfg == nil {
+		return nil
+	}
+	for k := range cfg.Metadata {
+		if k == "" || k != strings.ToLower(k) {
+			return errors.New("metadata keys must be non-empty and lowercase")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Heartbeat outcomes a job can report.
+const (
+	HeartbeatSuccess = "success"
+	HeartbeatFail    = "fail"
+)
+
+// Heartbeat is a ping received from a push-monitored job.
+type Heartbeat struct {
+	ServiceID  uuid.UUID     `json:"service_id"`
+	ReceivedAt time.Time     `json:"received_at"`
+	Status     string        `json:"status"`
+	Duration   time.Duration `json:"duration,omitempty"` // Job run time as reported by the job
+	Message    string        `json:"message,omitempty"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IP families a service can be checked over. The default lets the dialer
+// pick, which may silently fall back from IPv6 to IPv4.
+const (
+	IPFamilyAny  = ""
+	IPFamilyV4   = "ipv4"
+	IPFamilyV6   = "ipv6"
+	IPFamilyDual = "dual" // Check IPv4 and IPv6 separately, both must pass
+)
+
+// FamilyResult is the outcome of a dual-stack check over one address family.
+type FamilyResult struct {
+	Family       string        `json:"family"`
+	RemoteIP     string        `json:"remote_ip,omitempty"`
+	Latency      time.Duration `json:"latency"`
+	Success      bool          `json:"success"`
+	FailureKind  FailureKind   `json:"failure_kind,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+// validateIPFamily also rejects a family together with a proxy: the family
+// would only apply to the connection to the proxy, not to the target. Proxies
+// taken from HTTP_PROXY and related variables have the same effect and are
+// best not set for PulseGuard when families are checked.
+func validateIPFamily(serviceType string, c CheckConfig) error {
+	family := c.IPFamily
+	switch family {
+	case IPFamilyAny:
+		return nil
+	case IPFamilyV4, IPFamilyV6, IPFamilyDual:
+	default:
+		return fmt.Errorf("unsupported ip_family %q", family)
+	}
+	switch serviceType {
+	case ServiceTypeDNS, ServiceTypePush, ServiceTypeExec:
+		return fmt.Errorf("ip_family is not supported for %s services", serviceType)
+	}
+	if family != IPFamilyAny && c.usesProxy() {
+		return errors.New("ip_family cannot be combined with a proxy")
+	}
+	return nil
+}
+
+// usesProxy reports whether any request of the check goes through a proxy.
+func (c CheckConfig) usesProxy() bool {
+	if c.HTTP != nil && c.HTTP.Transport != nil && c.HTTP.Transport.Proxy != "" {
+		return true
+	}
+	if c.Scenario != nil {
+		for _, step := range c.Scenario.Steps {
+			if step.Transport != nil && step.Transport.Proxy != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultLocation names the server's own vantage point when none is configured.
+const DefaultLocation = "central"
+
+// Agent is a probe running checks from a remote location.
+type Agent struct {
+	Location     string    `json:"location"`
+	Address      string    `json:"address"` // Remote address of the last request
+	RegisteredAt time.Time `json:"registered_at"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+// MultiLocation reports whether agents check the service besides the server.
+func (c CheckConfig) MultiLocation() bool {
+	return len(c.Locations) > 0
+}
+
+// AssignedTo reports whether the agent at location should check the service.
+func (c CheckConfig) AssignedTo(location string) bool {
+	for _, l := range c.Locations {
+		if l == location {
+			return true
+		}
+	}
+	return false
+}
+
+// GetQuorum returns how many locations must fail before the service is DOWN.
+// It defaults to a majority of the agent locations plus the server.
+func (c CheckConfig) GetQuorum() int {
+	if c.Quorum > 0 {
+		return c.Quorum
+	}
+	return (len(c.Locations)+1)/2 + 1
+}
+
+func validateLocations(serviceType string, c CheckConfig) error {
+	if !c.MultiLocation() {
+		if c.Quorum != 0 {
+			return errors.New("quorum requires locations")
+		}
+		return nil
+	}
+	if serviceType == ServiceTypePush {
+		return errors.New("locations are not supported for PUSH services")
+	}
+	seen := make(map[string]bool, len(c.Locations))
+	for _, l := range c.Locations {
+		if l == "" {
+			return errors.New("location names must not be empty")
+		}
+		if seen[l] {
+			return fmt.Errorf("duplicate location %q", l)
+		}
+		seen[l] = true
+	}
+	if c.Quorum < 0 || c.Quorum > len(c.Locations)+1 {
+		return fmt.Errorf("quorum must be between 1 and %d, the number of locations including the server", len(c.Locations)+1)
+	}
+	return nil
+}
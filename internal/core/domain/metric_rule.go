@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PrometheusConfig describes a scrape of a Prometheus text-format endpoint at
+// Service.URL. Request settings such as headers and auth come from Config.HTTP.
+type PrometheusConfig struct {
+	// Rules in the form `selector op value => STATUS`, e.g.
+	// `queue_depth{queue="emails"} > 1000 => WARNING`.
+	Rules []string `json:"rules"`
+}
+
+// MetricRule is a parsed Prometheus rule. It fires when any series matching
+// Metric and Labels satisfies the comparison; absent series never fire.
+type MetricRule struct {
+	Metric   string
+	Labels   map[string]string
+	Operator string // One of >, >=, <, <=, ==, !=
+	Value    float64
+	Status   ServiceStatus
+}
+
+// metricRuleOperators is ordered so that two-character operators match first.
+var metricRuleOperators = []string{">=", "<=", "==", "!=", ">", "<"}
+
+// ParseMetricRule parses a rule such as `queue_depth{queue="emails"} > 1000 => WARNING`.
+func ParseMetricRule(rule string) (MetricRule, error) {
+	sep := strings.LastIndex(rule, "=>")
+	if sep < 0 {
+		return MetricRule{}, fmt.Errorf("rule %q: missing => STATUS", rule)
+	}
+	expr, statusStr := rule[:sep], rule[sep+len("=>"):]
+
+	var r MetricRule
+	r.Status = ServiceStatus(strings.ToUpper(strings.TrimSpace(statusStr)))
+	switch r.Status {
+	case StatusWarning, StatusCritical, StatusDown:
+	default:
+		return MetricRule{}, fmt.Errorf("rule %q: status must be WARNING, CRITICAL or DOWN", rule)
+	}
+
+	// The comparison follows the selector, so skip past any label braces
+	expr = strings.TrimSpace(expr)
+	searchFrom := 0
+	if i := strings.LastIndex(expr, "}"); i >= 0 {
+		searchFrom = i + 1
+	}
+	opIdx := -1
+	for _, op := range metricRuleOperators {
+		if i := strings.Index(expr[searchFrom:], op); i >= 0 && (opIdx < 0 || searchFrom+i < opIdx) {
+			opIdx = searchFrom + i
+			r.Operator = op
+		}
+	}
+	if opIdx < 0 {
+		return MetricRule{}, fmt.Errorf("rule %q: missing comparison operator", rule)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(expr[opIdx+len(r.Operator):]), 64)
+	if err != nil {
+		return MetricRule{}, fmt.Errorf("rule %q: invalid threshold: %w", rule, err)
+	}
+	r.Value = value
+
+	r.Metric, r.Labels, err = ParseSelector(strings.TrimSpace(expr[:opIdx]))
+	if err != nil {
+		return MetricRule{}, fmt.Errorf("rule %q: %w", rule, err)
+	}
+	return r, nil
+}
+
+// Compare reports whether value satisfies the rule's comparison.
+func (r MetricRule) Compare(value float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	switch r.Operator {
+	case ">":
+		return value > r.Value
+	case ">=":
+		return value >= r.Value
+	case "<":
+		return value < r.Value
+	case "<=":
+		return value <= r.Value
+	case "==":
+		return value == r.Value
+	case "!=":
+		return value != r.Value
+	default:
+		return false
+	}
+}
+
+// Matches reports whether a series with the given name and labels is selected by the rule.
+func (r MetricRule) Matches(name string, labels map[string]string) bool {
+	if name != r.Metric {
+		return false
+	}
+	for k, v := range r.Labels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSelector parses a series such as `name{label="value",...}`. In rules only
+// equality matchers are supported.
+func ParseSelector(s string) (string, map[string]string, error) {
+	name, rest, hasLabels := strings.Cut(s, "{")
+	name = strings.TrimSpace(name)
+	if !isMetricName(name) {
+		return "", nil, fmt.Errorf("invalid metric name %q", name)
+	}
+	if !hasLabels {
+		return name, nil, nil
+	}
+
+	body, ok := strings.CutSuffix(strings.TrimSpace(rest), "}")
+	if !ok {
+		return "", nil, errors.New("unterminated label matcher")
+	}
+
+	labels := make(map[string]string)
+	for body = strings.TrimSpace(body); body != ""; {
+		key, after, ok := strings.Cut(body, "=")
+		key = strings.TrimSpace(key)
+		after = strings.TrimSpace(after)
+		if !ok || key == "" || !strings.HasPrefix(after, `"`) {
+			return "", nil, errors.New(`label matchers must look like label="value"`)
+		}
+		value, n, err := unquoteLabelValue(after)
+		if err != nil {
+			return "", nil, err
+		}
+		labels[key] = value
+		body = strings.TrimPrefix(strings.TrimSpace(after[n:]), ",")
+		body = strings.TrimSpace(body)
+	}
+	return name, labels, nil
+}
+
+// unquoteLabelValue reads a double-quoted label value from the start of s and
+// returns it with the number of bytes consumed.
+func unquoteLabelValue(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 == len(s) {
+				break
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, errors.New("unterminated label value")
+}
+
+func isMetricName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if c == '_' || c == ':' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func validatePrometheus(rawURL string, cfg *PrometheusConfig, httpCfg *HTTPConfig) error {
+	if err := validateHTTP(rawURL, httpCfg); err != nil {
+		return err
+	}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return errors.New("prometheus services require at least one rule")
+	}
+	for _, rule := range cfg.Rules {
+		if _, err := ParseMetricRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
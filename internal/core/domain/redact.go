@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redactURL masks the password of a URL carrying userinfo. Other targets,
+// e.g. host:port or commands, are returned unchanged.
+func redactURL(raw string) string {
+	if !strings.Contains(raw, "://") {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	if _, ok := u.User.Password(); !ok {
+		return raw
+	}
+	u.User = url.UserPassword(u.User.Username(), RedactedSecret)
+	return u.String()
+}
+
+// redactHTTP returns a copy of c with its credentials masked, leaving c as is.
+// The body is masked whole since POST probes carry logins and API keys in it.
+func redactHTTP(c *HTTPConfig) *HTTPConfig {
+	if c == nil {
+		return nil
+	}
+	r := *c
+	r.Headers = redactValues(c.Headers)
+	r.Body = redactString(c.Body)
+	if c.Auth != nil {
+		auth := *c.Auth
+		auth.Password = redactString(auth.Password)
+		auth.Token = redactString(auth.Token)
+		r.Auth = &auth
+	}
+	if c.Transport != nil {
+		transport := *c.Transport
+		transport.ClientKey = redactString(transport.ClientKey)
+		transport.Proxy = redactURL(transport.Proxy)
+		r.Transport = &transport
+	}
+	return &r
+}
+
+// restoreHTTP puts back the credentials of prev that c holds in redacted form.
+func restoreHTTP(c, prev *HTTPConfig) {
+	if c == nil || prev == nil {
+		return
+	}
+	restoreValues(c.Headers, prev.Headers)
+	c.Body = restoreString(c.Body, prev.Body)
+	if c.Auth != nil && prev.Auth != nil {
+		auth := *c.Auth
+		auth.Password = restoreString(auth.Password, prev.Auth.Password)
+		auth.Token = restoreString(auth.Token, prev.Auth.Token)
+		c.Auth = &auth
+	}
+	if c.Transport != nil && prev.Transport != nil {
+		transport := *c.Transport
+		transport.ClientKey = restoreString(transport.ClientKey, prev.Transport.ClientKey)
+		if transport.Proxy != prev.Transport.Proxy && transport.Proxy == redactURL(prev.Transport.Proxy) {
+			transport.Proxy = prev.Transport.Proxy
+		}
+		c.Transport = &transport
+	}
+}
+
+// redactScenario returns a copy of c with the credentials and body of every
+// step masked, login steps usually posting the account's password.
+func redactScenario(c *ScenarioConfig) *ScenarioConfig {
+	if c == nil {
+		return nil
+	}
+	r := *c
+	r.Steps = make([]ScenarioStep, len(c.Steps))
+	for i, step := range c.Steps {
+		step.URL = redactURL(step.URL)
+		step.HTTPConfig = *redactHTTP(&step.HTTPConfig)
+		r.Steps[i] = step
+	}
+	return &r
+}
+
+// restoreScenario puts back the step credentials of prev, matching steps by
+// position, that c holds in redacted form.
+func restoreScenario(c, prev *ScenarioConfig) {
+	if c == nil || prev == nil {
+		return
+	}
+	for i := range c.Steps {
+		if i >= len(prev.Steps) {
+			return
+		}
+		step, old := &c.Steps[i], &prev.Steps[i]
+		if step.URL != old.URL && step.URL == redactURL(old.URL) {
+			step.URL = old.URL
+		}
+		restoreHTTP(&step.HTTPConfig, &old.HTTPConfig)
+	}
+}
+
+// redactValues returns a copy of m with every value masked.
+func redactValues(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	r := make(map[string]string, len(m))
+	for k, v := range m {
+		r[k] = redactString(v)
+	}
+	return r
+}
+
+// restoreValues puts back the values of prev that m holds in redacted form.
+func restoreValues(m, prev map[string]string) {
+	for k, v := range m {
+		if old, ok := prev[k]; ok {
+			m[k] = restoreString(v, old)
+		}
+	}
+}
+
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return RedactedSecret
+}
+
+func restoreString(s, prev string) string {
+	if s == RedactedSecret {
+		return prev
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Extraction sources for scenario variables.
+const (
+	ExtractJSONPath = "json_path"
+	ExtractRegex    = "regex" // First capture group, or the whole match without groups
+	ExtractHeader   = "header"
+)
+
+// ScenarioConfig describes a multi-step HTTP transaction. Steps run in order
+// and share variables; the first failing step fails the check. The service URL
+// is the base that relative step URLs are resolved against.
+type ScenarioConfig struct {
+	Steps []ScenarioStep `json:"steps"`
+}
+
+// ScenarioStep is a single request of a scenario. The URL, headers, body, auth
+// and assertion values may reference earlier extractions as {{name}}.
+type ScenarioStep struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	HTTPConfig
+	Extract []Extraction `json:"extract,omitempty"`
+}
+
+// Extraction stores a value from a step response into a scenario variable.
+type Extraction struct {
+	Var    string `json:"var"`
+	Source string `json:"source"`
+	Expr   string `json:"expr"` // JSON path, regex or header name
+}
+
+// StepResult is the outcome of one scenario step.
+type StepResult struct {
+	Name         string        `json:"name"`
+	StatusCode   int           `json:"status_code"`
+	Latency      time.Duration `json:"latency"`
+	Success      bool          `json:"success"`
+	FailureKind  FailureKind   `json:"failure_kind,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+var scenarioVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func validateScenario(rawURL string, cfg *ScenarioConfig) error {
+	if err := validateHTTP(rawURL, nil); err != nil {
+		return err
+	}
+	if cfg == nil || len(cfg.Steps) == 0 {
+		return errors.New("scenario requires at least one step")
+	}
+	for i, step := range cfg.Steps {
+		if step.URL == "" {
+			return fmt.Errorf("step %d: url is required", i+1)
+		}
+		// Step URLs may contain {{vars}}, so only the request settings are validated here
+		if err := validateHTTP(rawURL, &step.HTTPConfig); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		for _, ex := range step.Extract {
+			if !scenarioVarName.MatchString(ex.Var) {
+				return fmt.Errorf("step %d: invalid variable name %q", i+1, ex.Var)
+			}
+			switch ex.Source {
+			case ExtractJSONPath, ExtractHeader:
+				if ex.Expr == "" {
+					return fmt.Errorf("step %d: extraction of %s requires an expr", i+1, ex.Var)
+				}
+			case ExtractRegex:
+				if _, err := regexp.Compile(ex.Expr); err != nil {
+					return fmt.Errorf("step %d: invalid regex for %s: %w", i+1, ex.Var, err)
+				}
+			default:
+				return fmt.Errorf("step %d: unsupported extraction source %q", i+1, ex.Source)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package domain
+
+// SchedulerStats describes the monitoring engine's worker pool. Counters
+// accumulate from process start.
+type SchedulerStats struct {
+	Workers   int    `json:"workers"`
+	QueueSize int    `json:"queue_size"`
+	Monitored int    `json:"monitored"` // Services currently scheduled
+	Queued    int    `json:"queued"`    // Checks waiting for a worker
+	Running   int64  `json:"running"`   // Checks in progress
+	Executed  uint64 `json:"executed"`
+	Late      uint64 `json:"late"`     // Checks that found the queue full and were retried
+	Skipped   uint64 `json:"skipped"`  // Checks dropped because the queue stayed full for a whole interval
+	Overrun   uint64 `json:"overrun"`  // Checks dropped because the previous one had not finished
+	Deferred  uint64 `json:"deferred"` // Checks postponed because their host was at max_per_host
+}
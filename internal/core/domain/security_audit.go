@@ -0,0 +1,64 @@
+package domain
+
+import "errors"
+
+// DefaultHSTSMaxAge is the minimum HSTS max-age, in seconds, the default
+// policy accepts (180 days).
+const DefaultHSTSMaxAge = 15552000
+
+// SecurityAuditConfig enables the security header audit on an HTTP check.
+// Without a Policy the global policy applies.
+type SecurityAuditConfig struct {
+	Policy   *SecurityPolicy `json:"policy,omitempty"`
+	MinScore int             `json:"min_score,omitempty"` // A lower score reports the service as WARNING; 0 only records the audit
+}
+
+// SecurityPolicy lists the protections a response must carry. Each enabled
+// rule weighs the same in the score.
+type SecurityPolicy struct {
+	HSTS               bool  `json:"hsts"`
+	HSTSMinMaxAge      int64 `json:"hsts_min_max_age,omitempty"` // Seconds
+	CSP                bool  `json:"csp"`
+	ContentTypeOptions bool  `json:"content_type_options"` // X-Content-Type-Options: nosniff
+	FrameOptions       bool  `json:"frame_options"`        // X-Frame-Options or CSP frame-ancestors
+	SecureCookies      bool  `json:"secure_cookies"`
+	HTTPOnlyCookies    bool  `json:"httponly_cookies"`
+}
+
+// DefaultSecurityPolicy requires every protection the audit knows about.
+func DefaultSecurityPolicy() SecurityPolicy {
+	return SecurityPolicy{
+		HSTS:               true,
+		HSTSMinMaxAge:      DefaultHSTSMaxAge,
+		CSP:                true,
+		ContentTypeOptions: true,
+		FrameOptions:       true,
+		SecureCookies:      true,
+		HTTPOnlyCookies:    true,
+	}
+}
+
+// SecurityAudit is the outcome of auditing one response.
+type SecurityAudit struct {
+	Score    int               `json:"score"` // 0-100, share of the policy's rules that passed
+	Findings []SecurityFinding `json:"findings,omitempty"`
+}
+
+// SecurityFinding is a policy rule the response did not satisfy.
+type SecurityFinding struct {
+	Rule    string `json:"rule"` // Policy field, e.g. hsts or secure_cookies
+	Message string `json:"message"`
+}
+
+func validateSecurityAudit(c *SecurityAuditConfig) error {
+	if c == nil {
+		return nil
+	}
+	if c.MinScore < 0 || c.MinScore > 100 {
+		return errors.New("security_audit min_score must be between 0 and 100")
+	}
+	if c.Policy != nil && c.Policy.HSTSMinMaxAge < 0 {
+		return errors.New("security_audit hsts_min_max_age must not be negative")
+	}
+	return nil
+}
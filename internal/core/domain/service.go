@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,48 +19,212 @@ const (
 	StatusUnknown  ServiceStatus = "UNKNOWN"
 )
 
+// Severity orders statuses from best to worst, for picking the worse of two verdicts.
+func (s ServiceStatus) Severity() int {
+	switch s {
+	case StatusHealthy:
+		return 0
+	case StatusUnknown:
+		return 1
+	case StatusWarning:
+		return 2
+	case StatusCritical:
+		return 3
+	case StatusDown:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// WorseStatus returns whichever of a and b is more severe.
+func WorseStatus(a, b ServiceStatus) ServiceStatus {
+	if b.Severity() > a.Severity() {
+		return b
+	}
+	return a
+}
+
+// Service types. Each type is served by the checker registered for it in the monitor engine.
+const (
+	ServiceTypeHTTP       = "HTTP"
+	ServiceTypeTCP        = "TCP"
+	ServiceTypeDNS        = "DNS"
+	ServiceTypeTLS        = "TLS"
+	ServiceTypePush       = "PUSH"
+	ServiceTypeScenario   = "SCENARIO"
+	ServiceTypeExec       = "EXEC"
+	ServiceTypeGRPC       = "GRPC"
+	ServiceTypePostgres   = "POSTGRES"
+	ServiceTypeRedis      = "REDIS"
+	ServiceTypePrometheus = "PROMETHEUS"
+	ServiceTypeWS         = "WS"
+)
+
+// DefaultTimeout applies to services that don't set their own timeout.
+const DefaultTimeout = 5 * time.Second
+
+// FailureKind classifies why a check failed. Every failed check carries one,
+// falling back to FailureUnknown.
+type FailureKind string
+
+const (
+	FailureDNS             FailureKind = "DNS"              // Name resolution failed
+	FailureConnectRefused  FailureKind = "CONNECT_REFUSED"  // Nothing listening on the target port
+	FailureConnect         FailureKind = "CONNECT"          // Other connection errors: unreachable, reset
+	FailureTimeout         FailureKind = "TIMEOUT"          // The check exceeded its timeout
+	FailureTLS             FailureKind = "TLS"              // Handshake or certificate verification failed
+	FailureHTTPStatus      FailureKind = "HTTP_STATUS"      // Status code not accepted
+	FailureAssertion       FailureKind = "ASSERTION"        // Response didn't match an assertion or expected value
+	FailureBodyRead        FailureKind = "BODY_READ"        // Response body couldn't be read
+	FailureProtocol        FailureKind = "PROTOCOL"         // Unexpected protocol-level reply
+	FailureUnhealthy       FailureKind = "UNHEALTHY"        // The target reported itself unhealthy
+	FailureHeartbeatMissed FailureKind = "HEARTBEAT_MISSED" // A push service didn't check in on time
+	FailureConfig          FailureKind = "CONFIG"           // The service can't be checked as configured
+	FailureUnknown         FailureKind = "UNKNOWN"
+)
+
+// Default certificate expiry thresholds, in days.
+const (
+	DefaultCertExpiryWarningDays  = 14
+	DefaultCertExpiryCriticalDays = 7
+)
+
+// Latency phases the latency thresholds can target.
+const (
+	PhaseTotal    = "total"
+	PhaseDNS      = "dns"
+	PhaseConnect  = "connect"
+	PhaseTLS      = "tls"
+	PhaseTTFB     = "ttfb"
+	PhaseTransfer = "transfer"
+)
+
 type ServiceThresholds struct {
-	LatencyWarning  time.Duration `json:"latency_warning"`
-	LatencyCritical time.Duration `json:"latency_critical"`
+	LatencyWarning         time.Duration `json:"latency_warning"`
+	LatencyCritical        time.Duration `json:"latency_critical"`
+	LatencyPhase           string        `json:"latency_phase,omitempty"` // Defaults to total
+	CertExpiryWarningDays  int           `json:"cert_expiry_warning_days,omitempty"`
+	CertExpiryCriticalDays int           `json:"cert_expiry_critical_days,omitempty"`
+}
+
+// PhaseLatency returns the latency of the given phase, falling back to the
+// total latency when the result carries no breakdown.
+func (r CheckResult) PhaseLatency(phase string) time.Duration {
+	if r.Timings == nil {
+		return r.Latency
+	}
+	switch phase {
+	case PhaseDNS:
+		return r.Timings.DNS
+	case PhaseConnect:
+		return r.Timings.Connect
+	case PhaseTLS:
+		return r.Timings.TLS
+	case PhaseTTFB:
+		return r.Timings.TTFB
+	case PhaseTransfer:
+		return r.Timings.Transfer
+	default:
+		return r.Latency
+	}
+}
+
+func (t ServiceThresholds) GetCertExpiryWarningDays() int {
+	if t.CertExpiryWarningDays <= 0 {
+		return DefaultCertExpiryWarningDays
+	}
+	return t.CertExpiryWarningDays
+}
+
+func (t ServiceThresholds) GetCertExpiryCriticalDays() int {
+	if t.CertExpiryCriticalDays <= 0 {
+		return DefaultCertExpiryCriticalDays
+	}
+	return t.CertExpiryCriticalDays
 }
 
 type Service struct {
-	ID        uuid.UUID     `json:"id"`
-	Name      string        `json:"name"`
-	URL       string        `json:"url"`
-	Interval  time.Duration `json:"interval"`
-	Type       string            `json:"type"`
-	Thresholds ServiceThresholds `json:"thresholds"`
-	Status     ServiceStatus     `json:"status"`
-	SlackEnabled bool            `json:"slack_enabled"`
-	CreatedAt time.Time     `json:"created_at"`
-	UpdatedAt time.Time     `json:"updated_at"`
+	ID           uuid.UUID         `json:"id"`
+	Name         string            `json:"name"`
+	URL          string            `json:"url"`
+	Interval     time.Duration     `json:"interval"`
+	Timeout      time.Duration     `json:"timeout"`
+	Type         string            `json:"type"`
+	Thresholds   ServiceThresholds `json:"thresholds"`
+	Config       CheckConfig       `json:"config"`
+	Status       ServiceStatus     `json:"status"`
+	SlackEnabled bool              `json:"slack_enabled"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
 }
 
 type CheckResult struct {
-	ServiceID   uuid.UUID     `json:"service_id"`
-	CheckedAt   time.Time     `json:"checked_at"`
-	StatusCode  int           `json:"status_code"`
-	Latency     time.Duration `json:"latency"`
-	Success     bool          `json:"success"`
-	ErrorMessage string       `json:"error_message,omitempty"`
+	ServiceID    uuid.UUID      `json:"service_id"`
+	CheckedAt    time.Time      `json:"checked_at"`
+	StatusCode   int            `json:"status_code"`
+	Latency      time.Duration  `json:"latency"`
+	Success      bool           `json:"success"`
+	FailureKind  FailureKind    `json:"failure_kind,omitempty"`
+	ErrorMessage string         `json:"error_message,omitempty"`
+	Status       ServiceStatus  `json:"status,omitempty"` // Verdict reported by the checker itself, empty lets the analyzer decide
+	TLS          *TLSInfo       `json:"tls,omitempty"`
+	Timings      *HTTPTimings   `json:"timings,omitempty"`
+	Steps        []StepResult   `json:"steps,omitempty"`
+	Output       string         `json:"output,omitempty"` // First line of plugin output
+	PerfData     []PerfDatum    `json:"perfdata,omitempty"`
+	WS           *WSTimings     `json:"ws,omitempty"`
+	RemoteIP     string         `json:"remote_ip,omitempty"`    // Address actually connected to
+	Families     []FamilyResult `json:"families,omitempty"`     // Per-family outcomes of dual-stack checks
+	ContentHash  string         `json:"content_hash,omitempty"` // Hash of the watched body, see ContentWatchConfig
+	Content      string         `json:"-"`                      // Normalized body behind ContentHash
+	Security     *SecurityAudit `json:"security,omitempty"`
+	Location     string         `json:"location,omitempty"` // Vantage point: the server's location or an agent's
+}
+
+// HTTPTimings breaks the latency of an HTTP check down by phase. Checks never
+// reuse connections, so DNS and Connect are always measured; TLS is zero for
+// plain HTTP and DNS for IP literals.
+type HTTPTimings struct {
+	DNS      time.Duration `json:"dns"`
+	Connect  time.Duration `json:"connect"`
+	TLS      time.Duration `json:"tls"`
+	TTFB     time.Duration `json:"ttfb"`     // Request written to first response byte
+	Transfer time.Duration `json:"transfer"` // First response byte to end of body
+}
+
+// TLSInfo describes the certificate presented by the peer during a check.
+type TLSInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	SANs          []string  `json:"sans"`
+	NotBefore     time.Time `json:"not_before"`
+	NotAfter      time.Time `json:"not_after"`
+	DaysRemaining int       `json:"days_remaining"`
+	ChainValid    bool      `json:"chain_valid"`
+	HostnameMatch bool      `json:"hostname_match"`
+	VerifyError   string    `json:"verify_error,omitempty"`
 }
 
 type ServiceStats struct {
-	UptimePercentage float64       `json:"uptime_percentage"`
-	AvgLatency       time.Duration `json:"avg_latency"`
-	TotalChecks      int           `json:"total_checks"`
-	FailedChecks     int           `json:"failed_checks"`
-	Since            time.Time     `json:"since"`
+	UptimePercentage float64             `json:"uptime_percentage"`
+	AvgLatency       time.Duration       `json:"avg_latency"`
+	TotalChecks      int                 `json:"total_checks"`
+	FailedChecks     int                 `json:"failed_checks"`
+	FailuresByKind   map[FailureKind]int `json:"failures_by_kind"`
+	Since            time.Time           `json:"since"`
 }
 
-func NewService(name, url string, interval time.Duration, slackEnabled bool) *Service {
+func NewService(name, url, serviceType string, interval time.Duration, slackEnabled bool) *Service {
+	if serviceType == "" {
+		serviceType = ServiceTypeHTTP
+	}
 	return &Service{
-		ID:        uuid.New(),
-		Name:      name,
-		URL:       url,
-		Interval:  interval,
-		Type:     "HTTP",
+		ID:           uuid.New(),
+		Name:         name,
+		URL:          url,
+		Interval:     interval,
+		Type:         serviceType,
 		SlackEnabled: slackEnabled,
 		Thresholds: ServiceThresholds{
 			LatencyWarning:  500 * time.Millisecond,
@@ -68,3 +235,135 @@ func NewService(name, url string, interval time.Duration, slackEnabled bool) *Se
 		UpdatedAt: time.Now(),
 	}
 }
+
+// GetTimeout returns the per-check timeout, falling back to DefaultTimeout
+// capped at the interval.
+func (s *Service) GetTimeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	if s.Interval > 0 && s.Interval < DefaultTimeout {
+		return s.Interval
+	}
+	return DefaultTimeout
+}
+
+// DisplayURL returns the target with any credentials masked, for logs,
+// notifications and API responses.
+func (s *Service) DisplayURL() string {
+	switch s.Type {
+	case ServiceTypePostgres, ServiceTypeRedis:
+		return redactDSN(s.URL)
+	default:
+		return redactURL(s.URL)
+	}
+}
+
+// SameDefinition reports whether s and other are checked the same way. Status
+// and timestamps are ignored, as status transitions update them too.
+func (s *Service) SameDefinition(other *Service) bool {
+	a, b := *s, *other
+	a.Status, b.Status = "", ""
+	a.CreatedAt, b.CreatedAt = time.Time{}, time.Time{}
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	return reflect.DeepEqual(a, b)
+}
+
+// RedactedSecret replaces secrets in services returned by the API.
+const RedactedSecret = "xxxxx"
+
+// Redacted returns a copy of the service that is safe to expose through the
+// API. Header, metadata and exec environment values are masked too, as any of
+// them may carry credentials.
+func (s *Service) Redacted() *Service {
+	c := *s
+	c.URL = s.DisplayURL()
+	c.Config.HTTP = redactHTTP(s.Config.HTTP)
+	c.Config.Scenario = redactScenario(s.Config.Scenario)
+	if g := s.Config.GRPC; g != nil {
+		grpcCfg := *g
+		grpcCfg.Metadata = redactValues(g.Metadata)
+		c.Config.GRPC = &grpcCfg
+	}
+	if w := s.Config.WS; w != nil {
+		wsCfg := *w
+		wsCfg.Headers = redactValues(w.Headers)
+		c.Config.WS = &wsCfg
+	}
+	if e := s.Config.Exec; e != nil {
+		execCfg := *e
+		execCfg.Env = redactValues(e.Env)
+		c.Config.Exec = &execCfg
+	}
+	return &c
+}
+
+// RestoreSecrets puts back the secrets of prev that an update sent in their
+// redacted form, so a definition read from the API can be written back as is.
+func (s *Service) RestoreSecrets(prev *Service) {
+	if s.Type == prev.Type && s.URL != prev.URL && s.URL == prev.DisplayURL() {
+		s.URL = prev.URL
+	}
+	restoreHTTP(s.Config.HTTP, prev.Config.HTTP)
+	restoreScenario(s.Config.Scenario, prev.Config.Scenario)
+	if g, p := s.Config.GRPC, prev.Config.GRPC; g != nil && p != nil {
+		restoreValues(g.Metadata, p.Metadata)
+	}
+	if w, p := s.Config.WS, prev.Config.WS; w != nil && p != nil {
+		restoreValues(w.Headers, p.Headers)
+	}
+	if e, p := s.Config.Exec, prev.Config.Exec; e != nil && p != nil {
+		restoreValues(e.Env, p.Env)
+	}
+}
+
+// Validate checks that the service target is well-formed for its type.
+func (s *Service) Validate() error {
+	if s.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if s.Timeout > s.Interval {
+		return errors.New("timeout must not exceed interval")
+	}
+	switch s.Thresholds.LatencyPhase {
+	case "", PhaseTotal, PhaseDNS, PhaseConnect, PhaseTLS, PhaseTTFB, PhaseTransfer:
+	default:
+		return fmt.Errorf("unsupported latency phase %q", s.Thresholds.LatencyPhase)
+	}
+
+	if err := validateIPFamily(s.Type, s.Config); err != nil {
+		return err
+	}
+	if err := validateLocations(s.Type, s.Config); err != nil {
+		return err
+	}
+
+	switch s.Type {
+	case ServiceTypeHTTP:
+		return validateHTTP(s.URL, s.Config.HTTP)
+	case ServiceTypeTCP:
+		return validateTCP(s.URL, s.Config.TCP)
+	case ServiceTypeDNS:
+		return validateDNS(s.URL, s.Config.DNS)
+	case ServiceTypeTLS:
+		return validateTLS(s.URL)
+	case ServiceTypePush:
+		return validatePush(s.URL, s.Config.Push)
+	case ServiceTypeScenario:
+		return validateScenario(s.URL, s.Config.Scenario)
+	case ServiceTypeExec:
+		return validateExec(s.URL, s.Config.Exec)
+	case ServiceTypeGRPC:
+		return validateGRPC(s.URL, s.Config.GRPC)
+	case ServiceTypePostgres:
+		return validatePostgres(s.URL)
+	case ServiceTypeRedis:
+		return validateRedis(s.URL)
+	case ServiceTypePrometheus:
+		return validatePrometheus(s.URL, s.Config.Prometheus, s.Config.HTTP)
+	case ServiceTypeWS:
+		return validateWS(s.URL, s.Config.WS)
+	default:
+		return fmt.Errorf("unsupported service type %q", s.Type)
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// TransportConfig holds per-service connection settings for HTTP-based checks.
+// Services with identical settings share a transport.
+type TransportConfig struct {
+	Proxy              string `json:"proxy,omitempty"`           // http://, https:// or socks5:// proxy URL
+	CACert             string `json:"ca_cert,omitempty"`         // PEM certificates trusted in addition to the system roots
+	ClientCert         string `json:"client_cert,omitempty"`     // PEM client certificate for mutual TLS
+	ClientKey          string `json:"client_key,omitempty"`      // PEM private key of ClientCert
+	MinTLSVersion      string `json:"min_tls_version,omitempty"` // 1.0, 1.1, 1.2 or 1.3
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+// TLSVersion returns the tls package constant for MinTLSVersion, 0 when unset.
+func (c *TransportConfig) TLSVersion() uint16 {
+	if c == nil {
+		return 0
+	}
+	switch c.MinTLSVersion {
+	case "1.0":
+		return tls.VersionTLS10
+	case "1.1":
+		return tls.VersionTLS11
+	case "1.2":
+		return tls.VersionTLS12
+	case "1.3":
+		return tls.VersionTLS13
+	default:
+		return 0
+	}
+}
+
+// SkipsVerify reports whether certificate verification is disabled.
+func (c *HTTPConfig) SkipsVerify() bool {
+	return c != nil && c.Transport != nil && c.Transport.InsecureSkipVerify
+}
+
+func validateTransport(c *TransportConfig) error {
+	if c == nil {
+		return nil
+	}
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+			return errors.New("invalid proxy URL")
+		}
+	}
+	if c.CACert != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(c.CACert)) {
+		return errors.New("ca_cert contains no valid PEM certificates")
+	}
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return errors.New("client_cert and client_key must be set together")
+	}
+	if c.ClientCert != "" {
+		if _, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey)); err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+	}
+	if c.MinTLSVersion != "" && c.TLSVersion() == 0 {
+		return fmt.Errorf("unsupported min_tls_version %q", c.MinTLSVersion)
+	}
+	return nil
+}
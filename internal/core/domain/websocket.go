@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// WSConfig describes a WebSocket check against Service.URL (ws:// or wss://).
+// Without Send the check only performs the upgrade handshake.
+type WSConfig struct {
+	Headers      map[string]string `json:"headers,omitempty"`
+	Subprotocols []string          `json:"subprotocols,omitempty"`
+	Send         string            `json:"send,omitempty"`         // Text message sent after the handshake
+	Expect       string            `json:"expect,omitempty"`       // Substring the reply must contain
+	ExpectRegex  string            `json:"expect_regex,omitempty"` // Regex the reply must match
+}
+
+// ExpectsReply reports whether the check waits for a message after the handshake.
+func (c *WSConfig) ExpectsReply() bool {
+	return c != nil && (c.Send != "" || c.Expect != "" || c.ExpectRegex != "")
+}
+
+// WSTimings splits the latency of a WebSocket check.
+type WSTimings struct {
+	Handshake time.Duration `json:"handshake"`            // Dial through the completed upgrade
+	RoundTrip time.Duration `json:"round_trip,omitempty"` // Message sent to matching reply received
+}
+
+func validateWS(rawURL string, cfg *WSConfig) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "ws" && u.Scheme != "wss") {
+		return errors.New("invalid WebSocket URL, expected ws:// or wss://")
+	}
+	if cfg != nil && cfg.ExpectRegex != "" {
+		if _, err := regexp.Compile(cfg.ExpectRegex); err != nil {
+			return fmt.Errorf("invalid expect_regex: %w", err)
+		}
+	}
+	return nil
+}
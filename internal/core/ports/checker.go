@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// Checker performs a single health check against a service and reports the outcome.
+// Implementations must honor ctx cancellation and never panic on bad input;
+// failures are reported through the returned CheckResult.
+type Checker interface {
+	Check(ctx context.Context, service *domain.Service) domain.CheckResult
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// ClusterMembership tracks the PulseGuard nodes that share the monitoring work.
+type ClusterMembership interface {
+	NodeID() string
+	// Join announces this node and keeps announcing it until ctx is done.
+	Join(ctx context.Context) error
+	Leave(ctx context.Context) error
+	// Members returns the IDs of all live nodes, this one included.
+	Members(ctx context.Context) ([]string, error)
+}
@@ -9,4 +9,6 @@ import (
 type EventBus interface {
 	PublishCheckResult(ctx context.Context, result domain.CheckResult) error
 	SubscribeCheckResults(ctx context.Context) (<-chan domain.CheckResult, error)
+	PublishContentChange(ctx context.Context, change domain.ContentChange) error
+	SubscribeContentChanges(ctx context.Context) (<-chan domain.ContentChange, error)
 }
@@ -8,4 +8,5 @@ import (
 
 type NotificationService interface {
 	NotifyStatusChange(ctx context.Context, service *domain.Service, oldStatus, newStatus domain.ServiceStatus) error
+	NotifyContentChange(ctx context.Context, service *domain.Service, change domain.ContentChange) error
 }
@@ -11,13 +11,37 @@ import (
 type ServiceRepository interface {
 	Create(ctx context.Context, service *domain.Service) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error)
+	GetByPushToken(ctx context.Context, token string) (*domain.Service, error)
 	GetAll(ctx context.Context) ([]*domain.Service, error)
+	// Update replaces the stored definition of the service. The status is left
+	// to UpdateStatus, so a concurrent transition is never reverted.
 	Update(ctx context.Context, service *domain.Service) error
+	// UpdateStatus records a status transition without touching the definition.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ServiceStatus, at time.Time) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type MetricRepository interface {
 	Save(ctx context.Context, result *domain.CheckResult) error
 	GetHistory(ctx context.Context, serviceID uuid.UUID, limit int) ([]domain.CheckResult, error)
+	// GetLatestByLocation returns the newest check of each location reporting since the given time.
+	GetLatestByLocation(ctx context.Context, serviceID uuid.UUID, since time.Time) ([]domain.CheckResult, error)
 	GetStats(ctx context.Context, serviceID uuid.UUID, since time.Time) (*domain.ServiceStats, error)
 }
+
+type HeartbeatRepository interface {
+	Save(ctx context.Context, heartbeat *domain.Heartbeat) error
+	// GetLatest returns nil without error when the service has never sent a heartbeat.
+	GetLatest(ctx context.Context, serviceID uuid.UUID) (*domain.Heartbeat, error)
+}
+
+type ContentSnapshotRepository interface {
+	// Save stores snapshot and drops all but the newest keep snapshots of the service.
+	Save(ctx context.Context, snapshot *domain.ContentSnapshot, keep int) error
+	// GetLatest returns nil without error when the service has no snapshot yet.
+	GetLatest(ctx context.Context, serviceID uuid.UUID) (*domain.ContentSnapshot, error)
+	// GetHistory lists snapshots newest first, without their content.
+	GetHistory(ctx context.Context, serviceID uuid.UUID, limit int) ([]domain.ContentSnapshot, error)
+	// GetByHash returns nil without error when no snapshot has the hash.
+	GetByHash(ctx context.Context, serviceID uuid.UUID, hash string) (*domain.ContentSnapshot, error)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+var (
+	ErrAgentsDisabled   = errors.New("agents are disabled on this server")
+	ErrInvalidAgentAuth = errors.New("invalid agent token")
+	ErrReservedLocation = errors.New("location is reserved for the server")
+)
+
+// AgentService serves probe agents: it hands them the services assigned to
+// their location and feeds their results into the same pipeline as the
+// server's own checks. Agents are known only while the server runs.
+type AgentService struct {
+	repo     ports.ServiceRepository
+	token    string
+	location string // The server's own location
+	onResult func(domain.CheckResult)
+
+	mu     sync.Mutex
+	agents map[string]*domain.Agent
+}
+
+// NewAgentService accepts agents presenting token; an empty token disables
+// them. Agents may not report from location, the server's own.
+func NewAgentService(repo ports.ServiceRepository, token, location string, onResult func(domain.CheckResult)) *AgentService {
+	return &AgentService{
+		repo:     repo,
+		token:    token,
+		location: location,
+		onResult: onResult,
+		agents:   make(map[string]*domain.Agent),
+	}
+}
+
+// Authenticate checks the token an agent presented and the location it
+// reports from.
+func (s *AgentService) Authenticate(token, location string) error {
+	if s.token == "" {
+		return ErrAgentsDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		return ErrInvalidAgentAuth
+	}
+	if location == s.location {
+		return ErrReservedLocation
+	}
+	return nil
+}
+
+// Register records the agent at location, replacing a previous registration.
+func (s *AgentService) Register(location, address string) (*domain.Agent, error) {
+	if location == "" {
+		return nil, errors.New("agent location is required")
+	}
+	now := time.Now()
+	agent := &domain.Agent{Location: location, Address: address, RegisteredAt: now, LastSeen: now}
+
+	s.mu.Lock()
+	s.agents[location] = agent
+	s.mu.Unlock()
+
+	return agent, nil
+}
+
+// AssignedServices returns the services the agent at location should check,
+// secrets included since the agent runs them.
+func (s *AgentService) AssignedServices(ctx context.Context, location, address string) ([]*domain.Service, error) {
+	s.touch(location, address)
+
+	services, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assigned := make([]*domain.Service, 0)
+	for _, svc := range services {
+		if svc.Type != domain.ServiceTypePush && svc.Config.AssignedTo(location) {
+			assigned = append(assigned, svc)
+		}
+	}
+	return assigned, nil
+}
+
+// SubmitResults stamps results with the agent's location and hands them on,
+// returning how many were accepted. Results for services no longer assigned
+// to the location, e.g. checks that were in flight when it changed, are skipped.
+func (s *AgentService) SubmitResults(ctx context.Context, location, address string, results []domain.CheckResult) int {
+	s.touch(location, address)
+
+	accepted := 0
+	for _, result := range results {
+		svc, err := s.repo.GetByID(ctx, result.ServiceID)
+		if err != nil || !svc.Config.AssignedTo(location) {
+			slog.Warn("Agent: Skipping result for unassigned service", "location", location, "service_id", result.ServiceID)
+			continue
+		}
+		result.Location = location
+		if result.CheckedAt.IsZero() {
+			result.CheckedAt = time.Now()
+		}
+		if s.onResult != nil {
+			s.onResult(result)
+		}
+		accepted++
+	}
+	return accepted
+}
+
+// ListAgents returns the registered agents ordered by location.
+func (s *AgentService) ListAgents() []domain.Agent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	agents := make([]domain.Agent, 0, len(s.agents))
+	for _, a := range s.agents {
+		agents = append(agents, *a)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Location < agents[j].Location })
+	return agents
+}
+
+// touch updates when the agent was last heard from. Agents that were
+// registered before a server restart are recorded again.
+func (s *AgentService) touch(location, address string) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	agent, ok := s.agents[location]
+	if !ok {
+		agent = &domain.Agent{Location: location, RegisteredAt: now}
+		s.agents[location] = agent
+	}
+	agent.Address = address
+	agent.LastSeen = now
+}
@@ -34,6 +34,9 @@ func (s *AnalyzerService) AnalyzeResult(ctx context.Context, result domain.Check
 	}
 
 	newStatus := s.determineStatus(service, result)
+	if service.Config.MultiLocation() {
+		newStatus = s.determineQuorumStatus(ctx, service, result)
+	}
 
 	if service.Status != newStatus {
 		oldStatus := service.Status
@@ -48,7 +51,7 @@ func (s *AnalyzerService) AnalyzeResult(ctx context.Context, result domain.Check
 		)
 
 
-		if err := s.repo.Update(ctx, service); err != nil {
+		if err := s.repo.UpdateStatus(ctx, service.ID, newStatus, result.CheckedAt); err != nil {
 			slog.Error("Failed to update service status", "id", service.ID, "error", err)
 		} else {
 			// Notify success update
@@ -68,13 +71,79 @@ func (s *AnalyzerService) determineStatus(service *domain.Service, result domain
 		return domain.StatusDown
 	}
 
-	if result.StatusCode >= 500 {
+	// A 5xx is only DOWN by default; accepted_status may list it, e.g. for a
+	// maintenance page answering 503
+	if result.StatusCode >= 500 && !service.Config.HTTP.HasAcceptedStatus() {
+		return domain.StatusDown
+	}
+
+	status := domain.StatusHealthy
+	latency := result.PhaseLatency(service.Thresholds.LatencyPhase)
+	if latency >= service.Thresholds.LatencyCritical {
+		status = domain.StatusCritical
+	} else if latency >= service.Thresholds.LatencyWarning {
+		status = domain.StatusWarning
+	}
+
+	status = domain.WorseStatus(status, determineTLSStatus(service, result.TLS))
+	if result.Status != "" {
+		status = domain.WorseStatus(status, result.Status)
+	}
+	return status
+}
+
+// determineQuorumStatus combines the latest result of every location that
+// reported within two intervals. The service is DOWN once the quorum of
+// locations fails; fewer failing locations report it as WARNING at most.
+// Locations that stopped reporting don't count, so the quorum shrinks to the
+// locations left and an outage seen by all of them still reads as DOWN.
+func (s *AnalyzerService) determineQuorumStatus(ctx context.Context, service *domain.Service, result domain.CheckResult) domain.ServiceStatus {
+	latest, err := s.metricRepo.GetLatestByLocation(ctx, service.ID, result.CheckedAt.Add(-2*service.Interval))
+	if err != nil {
+		slog.Error("Analyzer: Failed to load results by location", "service_id", service.ID, "error", err)
+		return service.Status
+	}
+
+	// The result at hand counts even if saving it failed
+	byLocation := map[string]domain.CheckResult{result.Location: result}
+	for _, r := range latest {
+		if r.Location != result.Location {
+			byLocation[r.Location] = r
+		}
+	}
+
+	down := 0
+	status := domain.StatusHealthy
+	for _, r := range byLocation {
+		if locationStatus := s.determineStatus(service, r); locationStatus == domain.StatusDown {
+			down++
+		} else {
+			status = domain.WorseStatus(status, locationStatus)
+		}
+	}
+	if quorum := min(service.Config.GetQuorum(), len(byLocation)); down >= quorum {
 		return domain.StatusDown
 	}
-	if result.Latency >= service.Thresholds.LatencyCritical {
+	if down > 0 {
+		status = domain.WorseStatus(status, domain.StatusWarning)
+	}
+	return status
+}
+
+// determineTLSStatus judges the peer certificate against the service's expiry thresholds.
+// An invalid chain or a hostname mismatch is always critical.
+func determineTLSStatus(service *domain.Service, info *domain.TLSInfo) domain.ServiceStatus {
+	if info == nil {
+		return domain.StatusHealthy
+	}
+	// Services that opted out of verification are only judged on expiry
+	if (!info.ChainValid || !info.HostnameMatch) && !service.Config.HTTP.SkipsVerify() {
+		return domain.StatusCritical
+	}
+	if info.DaysRemaining <= service.Thresholds.GetCertExpiryCriticalDays() {
 		return domain.StatusCritical
 	}
-	if result.Latency >= service.Thresholds.LatencyWarning {
+	if info.DaysRemaining <= service.Thresholds.GetCertExpiryWarningDays() {
 		return domain.StatusWarning
 	}
 	return domain.StatusHealthy
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// locationMetrics serves stored results the way GetLatestByLocation does.
+type locationMetrics struct {
+	results []domain.CheckResult
+}
+
+func (m *locationMetrics) Save(ctx context.Context, result *domain.CheckResult) error {
+	m.results = append(m.results, *result)
+	return nil
+}
+
+func (m *locationMetrics) GetHistory(ctx context.Context, serviceID uuid.UUID, limit int) ([]domain.CheckResult, error) {
+	return nil, nil
+}
+
+func (m *locationMetrics) GetStats(ctx context.Context, serviceID uuid.UUID, since time.Time) (*domain.ServiceStats, error) {
+	return nil, nil
+}
+
+func (m *locationMetrics) GetLatestByLocation(ctx context.Context, serviceID uuid.UUID, since time.Time) ([]domain.CheckResult, error) {
+	latest := make(map[string]domain.CheckResult)
+	for _, r := range m.results {
+		if r.ServiceID != serviceID || r.CheckedAt.Before(since) {
+			continue
+		}
+		if prev, ok := latest[r.Location]; !ok || r.CheckedAt.After(prev.CheckedAt) {
+			latest[r.Location] = r
+		}
+	}
+	results := make([]domain.CheckResult, 0, len(latest))
+	for _, r := range latest {
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func TestDetermineQuorumStatus(t *testing.T) {
+	now := time.Now()
+	check := func(location string, up bool, age time.Duration) domain.CheckResult {
+		return domain.CheckResult{Location: location, Success: up, CheckedAt: now.Add(-age)}
+	}
+
+	tests := []struct {
+		name      string
+		locations []string
+		quorum    int
+		stored    []domain.CheckResult
+		current   domain.CheckResult
+		want      domain.ServiceStatus
+	}{
+		{
+			name:      "all locations up",
+			locations: []string{"eu", "us"},
+			stored:    []domain.CheckResult{check("eu", true, time.Second), check("us", true, time.Second)},
+			current:   check("central", true, 0),
+			want:      domain.StatusHealthy,
+		},
+		{
+			name:      "one of three down",
+			locations: []string{"eu", "us"},
+			stored:    []domain.CheckResult{check("eu", true, time.Second), check("us", false, time.Second)},
+			current:   check("central", true, 0),
+			want:      domain.StatusWarning,
+		},
+		{
+			name:      "two of three down",
+			locations: []string{"eu", "us"},
+			stored:    []domain.CheckResult{check("eu", true, time.Second), check("us", false, time.Second)},
+			current:   check("central", false, 0),
+			want:      domain.StatusDown,
+		},
+		{
+			name:      "explicit quorum not reached",
+			locations: []string{"eu", "us"},
+			quorum:    3,
+			stored:    []domain.CheckResult{check("eu", true, time.Second), check("us", false, time.Second)},
+			current:   check("central", false, 0),
+			want:      domain.StatusWarning,
+		},
+		{
+			name:      "agent never reported",
+			locations: []string{"eu"},
+			current:   check("central", false, 0),
+			want:      domain.StatusDown,
+		},
+		{
+			name:      "stale agent result ignored",
+			locations: []string{"eu"},
+			stored:    []domain.CheckResult{check("eu", true, 5*time.Minute)},
+			current:   check("central", false, 0),
+			want:      domain.StatusDown,
+		},
+		{
+			name:      "current result replaces stored one",
+			locations: []string{"eu", "us"},
+			stored:    []domain.CheckResult{check("eu", false, time.Second), check("us", false, time.Second)},
+			current:   check("eu", true, 0),
+			want:      domain.StatusWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := domain.NewService("svc", "http://example.invalid", domain.ServiceTypeHTTP, time.Minute, false)
+			service.Config.Locations = tt.locations
+			service.Config.Quorum = tt.quorum
+
+			metrics := &locationMetrics{}
+			for _, r := range tt.stored {
+				r.ServiceID = service.ID
+				metrics.results = append(metrics.results, r)
+			}
+			tt.current.ServiceID = service.ID
+
+			analyzer := NewAnalyzerService(nil, metrics, nil)
+			if got := analyzer.determineQuorumStatus(context.Background(), service, tt.current); got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetermineStatusServerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted []string
+		want     domain.ServiceStatus
+	}{
+		{name: "default range", want: domain.StatusDown},
+		{name: "accepted maintenance page", accepted: []string{"200-299", "503"}, want: domain.StatusHealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := domain.NewService("svc", "http://example.invalid", domain.ServiceTypeHTTP, time.Minute, false)
+			service.Config.HTTP = &domain.HTTPConfig{AcceptedStatus: tt.accepted}
+			result := domain.CheckResult{Success: true, StatusCode: 503, Latency: time.Millisecond}
+
+			analyzer := NewAnalyzerService(nil, nil, nil)
+			if got := analyzer.determineStatus(service, result); got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+// ContentChangeService compares watched responses against the latest stored
+// snapshot and raises a ContentChange when they differ.
+type ContentChangeService struct {
+	repo      ports.ServiceRepository
+	snapshots ports.ContentSnapshotRepository
+	eventBus  ports.EventBus
+	notifier  ports.NotificationService
+}
+
+func NewContentChangeService(repo ports.ServiceRepository, snapshots ports.ContentSnapshotRepository, eventBus ports.EventBus, notifier ports.NotificationService) *ContentChangeService {
+	return &ContentChangeService{
+		repo:      repo,
+		snapshots: snapshots,
+		eventBus:  eventBus,
+		notifier:  notifier,
+	}
+}
+
+// Track records the content of result. A new snapshot is stored only when the
+// hash changed; the first snapshot of a service is a baseline and raises nothing.
+func (s *ContentChangeService) Track(ctx context.Context, result domain.CheckResult) {
+	if result.ContentHash == "" {
+		return
+	}
+
+	latest, err := s.snapshots.GetLatest(ctx, result.ServiceID)
+	if err != nil {
+		slog.Error("Content: Failed to load snapshot", "service_id", result.ServiceID, "error", err)
+		return
+	}
+	if latest != nil && latest.Hash == result.ContentHash {
+		return
+	}
+
+	service, err := s.repo.GetByID(ctx, result.ServiceID)
+	if err != nil {
+		slog.Error("Content: Service not found", "service_id", result.ServiceID, "error", err)
+		return
+	}
+
+	snapshot := &domain.ContentSnapshot{
+		ServiceID:  service.ID,
+		Hash:       result.ContentHash,
+		Content:    result.Content,
+		CapturedAt: result.CheckedAt,
+	}
+	keep := domain.DefaultContentSnapshots
+	if service.Config.HTTP != nil {
+		keep = service.Config.HTTP.ContentWatch.GetKeep()
+	}
+	if err := s.snapshots.Save(ctx, snapshot, keep); err != nil {
+		slog.Error("Content: Failed to save snapshot", "service", service.Name, "error", err)
+		return
+	}
+	if latest == nil {
+		return
+	}
+
+	change := domain.ContentChange{
+		ServiceID:    service.ID,
+		ServiceName:  service.Name,
+		PreviousHash: latest.Hash,
+		Hash:         result.ContentHash,
+		DetectedAt:   result.CheckedAt,
+	}
+	slog.Info("Content changed", "service", service.Name, "old", latest.Hash, "new", change.Hash)
+
+	if err := s.eventBus.PublishContentChange(ctx, change); err != nil {
+		slog.Error("Failed to publish content change", "service", service.Name, "error", err)
+	}
+	if service.SlackEnabled {
+		if err := s.notifier.NotifyContentChange(ctx, service, change); err != nil {
+			slog.Error("Failed to send content change notification", "error", err, "service", service.Name)
+		}
+	}
+}
+
+// GetSnapshots returns the stored snapshots of a service, newest first and
+// without their content.
+func (s *ContentChangeService) GetSnapshots(ctx context.Context, serviceID uuid.UUID) ([]domain.ContentSnapshot, error) {
+	return s.snapshots.GetHistory(ctx, serviceID, domain.MaxContentSnapshots)
+}
+
+// GetSnapshot returns the snapshot of a service with the given hash, content
+// included, or nil if there is none.
+func (s *ContentChangeService) GetSnapshot(ctx context.Context, serviceID uuid.UUID, hash string) (*domain.ContentSnapshot, error) {
+	return s.snapshots.GetByHash(ctx, serviceID, hash)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+var ErrUnknownHeartbeatToken = errors.New("unknown heartbeat token")
+
+type HeartbeatService struct {
+	repo       ports.ServiceRepository
+	heartbeats ports.HeartbeatRepository
+}
+
+func NewHeartbeatService(repo ports.ServiceRepository, heartbeats ports.HeartbeatRepository) *HeartbeatService {
+	return &HeartbeatService{
+		repo:       repo,
+		heartbeats: heartbeats,
+	}
+}
+
+// RecordHeartbeat stores a ping from the job behind token. An empty status counts as success.
+func (s *HeartbeatService) RecordHeartbeat(ctx context.Context, token, status string, duration time.Duration, message string) (*domain.Heartbeat, error) {
+	if status == "" {
+		status = domain.HeartbeatSuccess
+	}
+	if status != domain.HeartbeatSuccess && status != domain.HeartbeatFail {
+		return nil, fmt.Errorf("invalid heartbeat status %q", status)
+	}
+
+	service, err := s.repo.GetByPushToken(ctx, token)
+	if err != nil {
+		return nil, ErrUnknownHeartbeatToken
+	}
+
+	heartbeat := &domain.Heartbeat{
+		ServiceID:  service.ID,
+		ReceivedAt: time.Now(),
+		Status:     status,
+		Duration:   duration,
+		Message:    message,
+	}
+	if err := s.heartbeats.Save(ctx, heartbeat); err != nil {
+		return nil, err
+	}
+	return heartbeat, nil
+}
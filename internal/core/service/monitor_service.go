@@ -2,10 +2,9 @@ package service
 
 import (
 	"context"
-	"errors"
-	"net/http"
+	"fmt"
 	"time"
-	
+
 	"github.com/google/uuid"
 	"github.com/umutaraz/pulseguard/internal/core/domain"
 	"github.com/umutaraz/pulseguard/internal/core/ports"
@@ -13,36 +12,49 @@ import (
 
 type Scheduler interface {
 	StartMonitorForService(service *domain.Service)
+	UpdateMonitorForService(service *domain.Service)
 	StopMonitorForService(id uuid.UUID)
+	Stats() domain.SchedulerStats
 }
 
 type MonitorService struct {
 	repo       ports.ServiceRepository
 	metricRepo ports.MetricRepository
 	scheduler  Scheduler
+	location   string // The server's own location, which agents may not claim
 }
 
-func NewMonitorService(repo ports.ServiceRepository, metricRepo ports.MetricRepository, scheduler Scheduler) *MonitorService {
+func NewMonitorService(repo ports.ServiceRepository, metricRepo ports.MetricRepository, scheduler Scheduler, location string) *MonitorService {
 	return &MonitorService{
 		repo:       repo,
 		metricRepo: metricRepo,
 		scheduler:  scheduler,
+		location:   location,
 	}
 }
 
-func (s *MonitorService) RegisterService(ctx context.Context, name, url string, interval int, slackEnabled bool) (*domain.Service, error) {
-	// Validate URL
-	if _, err := http.NewRequest("GET", url, nil); err != nil {
-		return nil, errors.New("invalid URL")
-	}
+// ServiceSpec is the user-supplied definition of a monitored service.
+type ServiceSpec struct {
+	Name         string
+	URL          string
+	Type         string
+	Interval     int // Seconds
+	Timeout      int // Seconds, 0 uses the default
+	SlackEnabled bool
+	Thresholds   *domain.ServiceThresholds // Nil keeps the defaults
+	Config       domain.CheckConfig
+}
 
-	// Default interval if invalid
-	if interval < 1 {
-		interval = 60
+func (s *MonitorService) RegisterService(ctx context.Context, spec ServiceSpec) (*domain.Service, error) {
+	service := domain.NewService(spec.Name, spec.URL, spec.Type, spec.interval(), spec.SlackEnabled)
+	spec.applyTo(service)
+	if err := issuePushToken(service, nil); err != nil {
+		return nil, err
 	}
 
-	intervalDuration := time.Duration(interval) * time.Second
-	service := domain.NewService(name, url, intervalDuration, slackEnabled)
+	if err := s.validate(service); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Create(ctx, service); err != nil {
 		return nil, err
@@ -53,6 +65,105 @@ func (s *MonitorService) RegisterService(ctx context.Context, name, url string,
 	return service, nil
 }
 
+// UpdateService replaces the definition of a service. Its ID, status, history
+// and push token carry over, and the running monitor switches to the new
+// definition without a restart.
+func (s *MonitorService) UpdateService(ctx context.Context, id uuid.UUID, spec ServiceSpec) (*domain.Service, error) {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	service := *current
+	service.Name = spec.Name
+	service.URL = spec.URL
+	service.Type = spec.Type
+	if service.Type == "" {
+		service.Type = domain.ServiceTypeHTTP
+	}
+	service.Interval = spec.interval()
+	service.SlackEnabled = spec.SlackEnabled
+	spec.applyTo(&service)
+	if err := issuePushToken(&service, current); err != nil {
+		return nil, err
+	}
+	service.RestoreSecrets(current)
+	service.UpdatedAt = time.Now()
+
+	if err := s.validate(&service); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, &service); err != nil {
+		return nil, err
+	}
+
+	s.scheduler.UpdateMonitorForService(&service)
+
+	return &service, nil
+}
+
+// validate checks the service definition. Its agent locations must not
+// include the server's location, whose checks the server runs itself.
+func (s *MonitorService) validate(service *domain.Service) error {
+	if err := service.Validate(); err != nil {
+		return err
+	}
+	if service.Config.AssignedTo(s.location) {
+		return fmt.Errorf("location %q is the server's own location", s.location)
+	}
+	return nil
+}
+
+// interval returns the check interval, defaulting invalid values to a minute.
+func (spec ServiceSpec) interval() time.Duration {
+	interval := spec.Interval
+	if interval < 1 {
+		interval = 60
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// applyTo sets the timeout, config and thresholds of spec on service. Unset
+// thresholds keep the service's current values.
+func (spec ServiceSpec) applyTo(service *domain.Service) {
+	service.Timeout = time.Duration(spec.Timeout) * time.Second
+	service.Config = spec.Config
+	if t := spec.Thresholds; t != nil {
+		if t.LatencyWarning <= 0 {
+			t.LatencyWarning = service.Thresholds.LatencyWarning
+		}
+		if t.LatencyCritical <= 0 {
+			t.LatencyCritical = service.Thresholds.LatencyCritical
+		}
+		service.Thresholds = *t
+	}
+}
+
+// issuePushToken gives a PUSH service the token of prev, its stored version,
+// or a new one. Tokens are only ever issued here so that they stay unique; a
+// token sent by the client is ignored.
+func issuePushToken(service, prev *domain.Service) error {
+	if service.Type != domain.ServiceTypePush {
+		return nil
+	}
+	push := domain.PushConfig{}
+	if service.Config.Push != nil {
+		push = *service.Config.Push
+	}
+	if prev != nil && prev.Config.Push != nil && prev.Config.Push.Token != "" {
+		push.Token = prev.Config.Push.Token
+	} else {
+		token, err := domain.NewPushToken()
+		if err != nil {
+			return fmt.Errorf("failed to issue push token: %w", err)
+		}
+		push.Token = token
+	}
+	service.Config.Push = &push
+	return nil
+}
+
 func (s *MonitorService) ListServices(ctx context.Context) ([]*domain.Service, error) {
 	return s.repo.GetAll(ctx)
 }
@@ -66,6 +177,10 @@ func (s *MonitorService) GetServiceStats(ctx context.Context, serviceID uuid.UUI
 	return s.metricRepo.GetStats(ctx, serviceID, since)
 }
 
+func (s *MonitorService) GetSchedulerStats() domain.SchedulerStats {
+	return s.scheduler.Stats()
+}
+
 func (s *MonitorService) DeleteService(ctx context.Context, id uuid.UUID) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
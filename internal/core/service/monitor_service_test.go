@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/adapter/storage/memory"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// nopScheduler accepts every monitor change without running checks.
+type nopScheduler struct{}
+
+func (nopScheduler) StartMonitorForService(*domain.Service)  {}
+func (nopScheduler) UpdateMonitorForService(*domain.Service) {}
+func (nopScheduler) StopMonitorForService(uuid.UUID)         {}
+func (nopScheduler) Stats() domain.SchedulerStats            { return domain.SchedulerStats{} }
+
+func TestPushTokensAreIssuedByTheServer(t *testing.T) {
+	ctx := context.Background()
+	svc := NewMonitorService(memory.NewInMemoryServiceRepository(), nil, nopScheduler{}, domain.DefaultLocation)
+
+	chosen := domain.CheckConfig{Push: &domain.PushConfig{Token: "0123456789abcdef0123"}}
+	first, err := svc.RegisterService(ctx, ServiceSpec{Name: "backup", Type: domain.ServiceTypePush, Config: chosen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := svc.RegisterService(ctx, ServiceSpec{Name: "etl", Type: domain.ServiceTypePush, Config: chosen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := first.Config.Push.Token
+	if token == chosen.Push.Token || second.Config.Push.Token == chosen.Push.Token {
+		t.Fatal("client supplied push token was kept")
+	}
+	if token == second.Config.Push.Token {
+		t.Fatal("services share a push token")
+	}
+
+	updated, err := svc.UpdateService(ctx, first.ID, ServiceSpec{Name: "backup", Type: domain.ServiceTypePush, Config: chosen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Config.Push.Token != token {
+		t.Errorf("token after update = %q, want %q", updated.Config.Push.Token, token)
+	}
+}
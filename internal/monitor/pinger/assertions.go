@@ -0,0 +1,111 @@
+package pinger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// evaluateAssertions returns an error describing the first assertion the response fails.
+func evaluateAssertions(assertions []domain.Assertion, header http.Header, body []byte) error {
+	var doc any
+	var docErr error
+	docParsed := false
+
+	for _, a := range assertions {
+		switch a.Type {
+		case domain.AssertBodyContains:
+			if !bytes.Contains(body, []byte(a.Value)) {
+				return fmt.Errorf("assertion failed: body does not contain %q", a.Value)
+			}
+		case domain.AssertBodyNotContains:
+			if bytes.Contains(body, []byte(a.Value)) {
+				return fmt.Errorf("assertion failed: body contains %q", a.Value)
+			}
+		case domain.AssertBodyRegex:
+			re, err := regexp.Compile(a.Value)
+			if err != nil {
+				return fmt.Errorf("assertion failed: invalid regex %q: %v", a.Value, err)
+			}
+			if !re.Match(body) {
+				return fmt.Errorf("assertion failed: body does not match %q", a.Value)
+			}
+		case domain.AssertJSONPath:
+			if !docParsed {
+				docErr = json.Unmarshal(body, &doc)
+				docParsed = true
+			}
+			if docErr != nil {
+				return fmt.Errorf("assertion failed: body is not valid JSON: %v", docErr)
+			}
+			v, err := lookupJSONPath(doc, a.Target)
+			if err != nil {
+				if a.GetOperator() == domain.OpExists {
+					return fmt.Errorf("assertion failed: %s does not exist", a.Target)
+				}
+				return fmt.Errorf("assertion failed: %v", err)
+			}
+			if err := compare(a, jsonValueString(v)); err != nil {
+				return err
+			}
+		case domain.AssertHeader:
+			values, ok := header[http.CanonicalHeaderKey(a.Target)]
+			if !ok {
+				return fmt.Errorf("assertion failed: header %s is missing", a.Target)
+			}
+			if err := compare(a, strings.Join(values, ", ")); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("assertion failed: unsupported assertion type %q", a.Type)
+		}
+	}
+	return nil
+}
+
+// compare applies the assertion operator to an actual value. Ordering operators
+// compare numerically and fail when either side is not a number.
+func compare(a domain.Assertion, actual string) error {
+	op := a.GetOperator()
+
+	var ok bool
+	switch op {
+	case domain.OpExists:
+		ok = true
+	case domain.OpEquals:
+		ok = actual == a.Value
+	case domain.OpNotEquals:
+		ok = actual != a.Value
+	case domain.OpContains:
+		ok = strings.Contains(actual, a.Value)
+	case domain.OpLess, domain.OpLessOrEq, domain.OpGreater, domain.OpGreaterOrEq:
+		got, err1 := strconv.ParseFloat(actual, 64)
+		want, err2 := strconv.ParseFloat(a.Value, 64)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("assertion failed: %s = %q is not comparable with %s %q", a.Target, actual, op, a.Value)
+		}
+		switch op {
+		case domain.OpLess:
+			ok = got < want
+		case domain.OpLessOrEq:
+			ok = got <= want
+		case domain.OpGreater:
+			ok = got > want
+		case domain.OpGreaterOrEq:
+			ok = got >= want
+		}
+	default:
+		return fmt.Errorf("assertion failed: unsupported operator %q", op)
+	}
+
+	if !ok {
+		return fmt.Errorf("assertion failed: %s = %q, expected %s %q", a.Target, truncate(actual, 100), op, a.Value)
+	}
+	return nil
+}
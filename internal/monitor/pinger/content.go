@@ -0,0 +1,51 @@
+package pinger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// watchContent sets ContentHash and Content on a successful result from the
+// watched body. Content that cannot be normalized leaves the hash empty, so
+// the check itself still passes and no change is reported.
+func watchContent(result *domain.CheckResult, body []byte, cfg *domain.ContentWatchConfig) error {
+	content, err := normalizeContent(body, cfg)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(content))
+	result.ContentHash = hex.EncodeToString(sum[:])
+	result.Content = content
+	return nil
+}
+
+// normalizeContent reduces body to the part that is compared between checks.
+func normalizeContent(body []byte, cfg *domain.ContentWatchConfig) (string, error) {
+	if cfg.JSONPath != "" {
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", errors.New("content watch: response body is not valid JSON")
+		}
+		v, err := lookupJSONPath(doc, cfg.JSONPath)
+		if err != nil {
+			return "", fmt.Errorf("content watch: %w", err)
+		}
+		return jsonValueString(v), nil
+	}
+
+	content := body
+	for _, expr := range cfg.Strip {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return "", fmt.Errorf("content watch: %w", err)
+		}
+		content = re.ReplaceAll(content, nil)
+	}
+	return string(content), nil
+}
@@ -0,0 +1,187 @@
+package pinger
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type DNSChecker struct{}
+
+func NewDNSChecker() *DNSChecker {
+	return &DNSChecker{}
+}
+
+func (c *DNSChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	cfg := service.Config.DNS
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	answers, err := c.lookup(ctx, c.resolver(cfg), cfg.GetRecordType(), service.URL)
+	latency := time.Since(start)
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+		Latency:   latency,
+		Success:   true,
+	}
+	if err != nil {
+		result.Success = false
+		result.FailureKind = classifyError(err)
+		result.ErrorMessage = errorMessage(err, service)
+		return result
+	}
+
+	if err := matchAnswers(answers, cfg); err != nil {
+		result.Success = false
+		result.FailureKind = domain.FailureAssertion
+		result.ErrorMessage = err.Error()
+	}
+	return result
+}
+
+// resolver returns a resolver that talks to the configured nameserver,
+// or the system resolver when none is set.
+func (c *DNSChecker) resolver(cfg *domain.DNSConfig) *net.Resolver {
+	if cfg == nil || cfg.Resolver == "" {
+		return net.DefaultResolver
+	}
+	addr := cfg.Resolver
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// lookup resolves name and returns the answers in a normalized string form.
+func (c *DNSChecker) lookup(ctx context.Context, r *net.Resolver, recordType, name string) ([]string, error) {
+	var answers []string
+
+	switch recordType {
+	case domain.DNSRecordA, domain.DNSRecordAAAA:
+		network := "ip4"
+		if recordType == domain.DNSRecordAAAA {
+			network = "ip6"
+		}
+		ips, err := r.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			answers = append(answers, ip.String())
+		}
+	case domain.DNSRecordCNAME:
+		cname, err := r.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, normalizeDNSName(cname))
+	case domain.DNSRecordMX:
+		mxs, err := r.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			answers = append(answers, normalizeDNSName(mx.Host))
+		}
+	case domain.DNSRecordTXT:
+		txts, err := r.LookupTXT(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, txts...)
+	case domain.DNSRecordSRV:
+		_, srvs, err := r.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			answers = append(answers, net.JoinHostPort(normalizeDNSName(srv.Target), strconv.Itoa(int(srv.Port))))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported DNS record type %q", recordType)
+	}
+
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("no %s records found for %s", recordType, name)
+	}
+	return answers, nil
+}
+
+// matchAnswers asserts the answers against the expected values of cfg.
+func matchAnswers(answers []string, cfg *domain.DNSConfig) error {
+	if cfg == nil || len(cfg.Expected) == 0 {
+		return nil
+	}
+
+	got := make(map[string]bool, len(answers))
+	for _, a := range answers {
+		got[normalizeAnswer(a, cfg.GetRecordType())] = true
+	}
+
+	var missing []string
+	want := make(map[string]bool, len(cfg.Expected))
+	for _, e := range cfg.Expected {
+		e = normalizeAnswer(e, cfg.GetRecordType())
+		want[e] = true
+		if !got[e] {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("expected %s records missing: %s (got %s)", cfg.GetRecordType(), strings.Join(missing, ", "), joinSorted(got))
+	}
+
+	if cfg.GetMatch() == domain.DNSMatchEquals {
+		var extra []string
+		for a := range got {
+			if !want[a] {
+				extra = append(extra, a)
+			}
+		}
+		if len(extra) > 0 {
+			sort.Strings(extra)
+			return fmt.Errorf("unexpected %s records: %s", cfg.GetRecordType(), strings.Join(extra, ", "))
+		}
+	}
+	return nil
+}
+
+func normalizeAnswer(a, recordType string) string {
+	switch recordType {
+	case domain.DNSRecordA, domain.DNSRecordAAAA:
+		if ip := net.ParseIP(a); ip != nil {
+			return ip.String()
+		}
+		return a
+	case domain.DNSRecordTXT:
+		return a
+	default:
+		return normalizeDNSName(a)
+	}
+}
+
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func joinSorted(set map[string]bool) string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,130 @@
+package pinger
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// startDNSServer answers A queries for the names in records over UDP and
+// returns NXDOMAIN for everything else. It returns the server's address.
+func startDNSServer(t *testing.T, records map[string][][4]byte) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if reply, err := answerDNS(buf[:n], records); err == nil {
+				conn.WriteTo(reply, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func answerDNS(query []byte, records map[string][][4]byte) ([]byte, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	q, err := p.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	header.Response = true
+	header.Authoritative = true
+	ips, ok := records[strings.TrimSuffix(q.Name.String(), ".")]
+	if !ok {
+		header.RCode = dnsmessage.RCodeNameError
+	}
+
+	b := dnsmessage.NewBuilder(nil, header)
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if q.Type != dnsmessage.TypeA {
+			break
+		}
+		rh := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 60}
+		if err := b.AResource(rh, dnsmessage.AResource{A: ip}); err != nil {
+			return nil, err
+		}
+	}
+	return b.Finish()
+}
+
+func TestDNSCheckerAgainstLocalServer(t *testing.T) {
+	resolver := startDNSServer(t, map[string][][4]byte{
+		"app.pulseguard.test":  {{192, 0, 2, 10}},
+		"pool.pulseguard.test": {{192, 0, 2, 10}, {192, 0, 2, 11}},
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		expected []string
+		match    string
+		wantOK   bool
+		wantKind domain.FailureKind
+	}{
+		{name: "expected record", target: "app.pulseguard.test", expected: []string{"192.0.2.10"}, wantOK: true},
+		{name: "any record", target: "app.pulseguard.test", wantOK: true},
+		{name: "mismatch", target: "app.pulseguard.test", expected: []string{"192.0.2.99"}, wantKind: domain.FailureAssertion},
+		{name: "contains subset", target: "pool.pulseguard.test", expected: []string{"192.0.2.10"}, wantOK: true},
+		{name: "equals with extra record", target: "pool.pulseguard.test", expected: []string{"192.0.2.10"}, match: domain.DNSMatchEquals, wantKind: domain.FailureAssertion},
+		{name: "nxdomain", target: "missing.pulseguard.test", wantKind: domain.FailureDNS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &domain.Service{
+				Name:     tt.name,
+				URL:      tt.target,
+				Type:     domain.ServiceTypeDNS,
+				Interval: time.Minute,
+				Timeout:  2 * time.Second,
+				Config: domain.CheckConfig{DNS: &domain.DNSConfig{
+					Resolver: resolver,
+					Expected: tt.expected,
+					Match:    tt.match,
+				}},
+			}
+			if err := service.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			result := NewDNSChecker().Check(context.Background(), service)
+			if result.Success != tt.wantOK {
+				t.Fatalf("success = %v, want %v (%s)", result.Success, tt.wantOK, result.ErrorMessage)
+			}
+			if result.FailureKind != tt.wantKind {
+				t.Errorf("failure kind = %q, want %q (%s)", result.FailureKind, tt.wantKind, result.ErrorMessage)
+			}
+		})
+	}
+}
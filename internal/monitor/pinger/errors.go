@@ -0,0 +1,98 @@
+package pinger
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// kindError tags an error with the FailureKind it should be reported as.
+type kindError struct {
+	kind domain.FailureKind
+	err  error
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+func (e *kindError) Unwrap() error { return e.err }
+
+// withKind tags err with kind. A nil err stays nil.
+func withKind(kind domain.FailureKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// classifyError maps a check error onto a FailureKind. Timeouts win over
+// tagged errors, so a body read cut off by the deadline is reported as a
+// timeout. Unrecognized errors are FailureUnknown.
+func classifyError(err error) domain.FailureKind {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return domain.FailureTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return domain.FailureTimeout
+	}
+
+	var kindErr *kindError
+	if errors.As(err, &kindErr) {
+		return kindErr.kind
+	}
+
+	var dnsErr *net.DNSError
+	var addrErr *net.AddrError // e.g. no address of the requested IP family
+	if errors.As(err, &dnsErr) || errors.As(err, &addrErr) {
+		return domain.FailureDNS
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return domain.FailureConnectRefused
+	}
+	if isTLSError(err) {
+		return domain.FailureTLS
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) {
+		return domain.FailureConnect
+	}
+	return domain.FailureUnknown
+}
+
+func isTLSError(err error) bool {
+	var (
+		verifyErr     *tls.CertificateVerificationError
+		recordErr     tls.RecordHeaderError
+		alertErr      tls.AlertError
+		unknownCA     x509.UnknownAuthorityError
+		hostnameErr   x509.HostnameError
+		invalidErr    x509.CertificateInvalidError
+		systemRoots   x509.SystemRootsError
+		constraintErr x509.ConstraintViolationError
+	)
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &unknownCA) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &systemRoots) ||
+		errors.As(err, &constraintErr)
+}
+
+// errorMessage renders err for CheckResult.ErrorMessage, replacing noisy
+// timeout errors with the configured timeout.
+func errorMessage(err error, service *domain.Service) string {
+	if classifyError(err) == domain.FailureTimeout {
+		return fmt.Sprintf("timeout after %s: %v", service.GetTimeout(), err)
+	}
+	return err.Error()
+}
@@ -0,0 +1,299 @@
+package pinger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// Nagios plugin exit codes.
+const (
+	execOK       = 0
+	execWarning  = 1
+	execCritical = 2
+	execUnknown  = 3
+)
+
+// defaultExecOutput caps plugin output when no limit is configured.
+const defaultExecOutput = 8192
+
+// execPath is the PATH given to plugins; the parent's PATH is never inherited.
+const execPath = "/usr/local/bin:/usr/bin:/bin"
+
+// ExecChecker runs Nagios-compatible plugins. Commands are confined to the
+// configured directory, run with a scrubbed environment and their output is capped.
+type ExecChecker struct {
+	commandDir string
+	maxOutput  int
+	passEnv    []string
+}
+
+func NewExecChecker(cfg config.ExecConfig) *ExecChecker {
+	dir := cfg.CommandDir
+	if dir != "" {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+	}
+	maxOutput := cfg.MaxOutputBytes
+	if maxOutput <= 0 {
+		maxOutput = defaultExecOutput
+	}
+	return &ExecChecker{
+		commandDir: dir,
+		maxOutput:  maxOutput,
+		passEnv:    cfg.PassEnv,
+	}
+}
+
+func (c *ExecChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+	}
+
+	path, err := c.resolve(service.URL)
+	if err != nil {
+		result.FailureKind = domain.FailureConfig
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	var args []string
+	if cfg := service.Config.Exec; cfg != nil {
+		args = cfg.Args
+	}
+	output := &cappedBuffer{limit: c.maxOutput}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = c.commandDir
+	cmd.Env = c.environment(service.Config.Exec)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.WaitDelay = time.Second // Don't wait on pipes held open by orphaned children
+
+	err = cmd.Run()
+	result.Latency = time.Since(start)
+
+	if ctx.Err() != nil {
+		result.FailureKind = domain.FailureTimeout
+		result.ErrorMessage = fmt.Sprintf("timeout after %s", service.GetTimeout())
+		return result
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		result.FailureKind = domain.FailureConfig
+		result.ErrorMessage = "failed to run command: " + err.Error()
+		return result
+	}
+
+	result.Output, result.PerfData = parsePluginOutput(output.String())
+
+	switch code := cmd.ProcessState.ExitCode(); code {
+	case execOK:
+		result.Success = true
+		result.Status = domain.StatusHealthy
+	case execWarning:
+		result.Success = true
+		result.Status = domain.StatusWarning
+	case execCritical:
+		result.Success = true
+		result.Status = domain.StatusCritical
+	case execUnknown:
+		result.Success = true
+		result.Status = domain.StatusUnknown
+	default:
+		result.FailureKind = domain.FailureProtocol
+		result.ErrorMessage = fmt.Sprintf("unexpected exit code %d", code)
+	}
+	if result.Status != domain.StatusHealthy && result.ErrorMessage == "" {
+		result.ErrorMessage = result.Output
+	}
+	return result
+}
+
+// resolve returns the absolute path of command, refusing anything that
+// resolves outside the command directory.
+func (c *ExecChecker) resolve(command string) (string, error) {
+	if c.commandDir == "" {
+		return "", errors.New("exec checks are disabled: exec.command_dir is not configured")
+	}
+
+	path := command
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.commandDir, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("command not found: %w", err)
+	}
+
+	rel, err := filepath.Rel(c.commandDir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("command %s is outside the allowed directory", command)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("command %s is not an executable file", command)
+	}
+	return resolved, nil
+}
+
+// environment builds the scrubbed plugin environment: a fixed PATH, the
+// allow-listed variables of this process and the service's own variables.
+// Service variables never override the first two, nor set protected names;
+// validation rejects those, this guards services stored before it did.
+func (c *ExecChecker) environment(cfg *domain.ExecConfig) []string {
+	env := []string{"PATH=" + execPath}
+	for _, name := range c.passEnv {
+		if v, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+v)
+		}
+	}
+	if cfg != nil {
+		for k, v := range cfg.Env {
+			if domain.ProtectedEnv(k) || slices.Contains(c.passEnv, k) {
+				continue
+			}
+			env = append(env, k+"="+v)
+		}
+	}
+	return env
+}
+
+// cappedBuffer keeps the first limit bytes written to it and discards the rest,
+// so a chatty plugin can't exhaust memory or block on a full pipe.
+type cappedBuffer struct {
+	buf   []byte
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - len(b.buf); room > 0 {
+		if len(p) > room {
+			b.buf = append(b.buf, p[:room]...)
+		} else {
+			b.buf = append(b.buf, p...)
+		}
+	}
+	return len(p), nil
+}
+
+func (b *cappedBuffer) String() string {
+	return string(b.buf)
+}
+
+// parsePluginOutput splits plugin output into the first line of text and the
+// performance data, which may follow a '|' on the first line and on any line
+// of the long output.
+func parsePluginOutput(output string) (string, []domain.PerfDatum) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	text, perf, _ := strings.Cut(lines[0], "|")
+	var perfParts []string
+	if perf != "" {
+		perfParts = append(perfParts, perf)
+	}
+	inPerf := false
+	for _, line := range lines[1:] {
+		if inPerf {
+			perfParts = append(perfParts, line)
+			continue
+		}
+		if _, after, found := strings.Cut(line, "|"); found {
+			perfParts = append(perfParts, after)
+			inPerf = true
+		}
+	}
+
+	var data []domain.PerfDatum
+	for _, part := range perfParts {
+		data = append(data, parsePerfData(part)...)
+	}
+	return strings.TrimSpace(text), data
+}
+
+// parsePerfData parses space separated 'label'=value[UOM];warn;crit;min;max
+// entries. Malformed entries are skipped.
+func parsePerfData(s string) []domain.PerfDatum {
+	var data []domain.PerfDatum
+	for _, field := range splitPerfFields(s) {
+		label, rest, ok := strings.Cut(field, "=")
+		if !ok || label == "" {
+			continue
+		}
+		parts := strings.Split(rest, ";")
+
+		raw := parts[0]
+		end := strings.IndexFunc(raw, func(r rune) bool {
+			return !(r >= '0' && r <= '9' || r == '.' || r == '-' || r == '+' || r == 'e' || r == 'E')
+		})
+		if end < 0 {
+			end = len(raw)
+		}
+		value, err := strconv.ParseFloat(raw[:end], 64)
+		if err != nil {
+			continue
+		}
+
+		d := domain.PerfDatum{
+			Label: strings.ReplaceAll(strings.Trim(label, "'"), "''", "'"),
+			Value: value,
+			Unit:  raw[end:],
+		}
+		for i, dst := range []*string{&d.Warn, &d.Crit, &d.Min, &d.Max} {
+			if i+1 < len(parts) {
+				*dst = parts[i+1]
+			}
+		}
+		data = append(data, d)
+	}
+	return data
+}
+
+// splitPerfFields splits on whitespace outside single-quoted labels.
+func splitPerfFields(s string) []string {
+	var fields []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if cur.Len() > 0 {
+				fields = append(fields, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		fields = append(fields, cur.String())
+	}
+	return fields
+}
@@ -0,0 +1,136 @@
+package pinger
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+type ipFamilyKey struct{}
+type remoteAddrKey struct{}
+
+// remoteAddr records the address the last connection of a check went to.
+type remoteAddr struct {
+	mu sync.Mutex
+	ip string
+}
+
+func (r *remoteAddr) get() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ip
+}
+
+// withIPFamily restricts the connections opened under ctx to family.
+func withIPFamily(ctx context.Context, family string) context.Context {
+	return context.WithValue(ctx, ipFamilyKey{}, family)
+}
+
+// familyNetwork narrows a "tcp" or "udp" network to the family selected in ctx.
+func familyNetwork(ctx context.Context, network string) string {
+	family, _ := ctx.Value(ipFamilyKey{}).(string)
+	if network != "tcp" && network != "udp" {
+		return network
+	}
+	switch family {
+	case domain.IPFamilyV4:
+		return network + "4"
+	case domain.IPFamilyV6:
+		return network + "6"
+	default:
+		return network
+	}
+}
+
+// recordRemote notes addr as the remote address of the check running under ctx.
+func recordRemote(ctx context.Context, addr net.Addr) {
+	r, ok := ctx.Value(remoteAddrKey{}).(*remoteAddr)
+	if !ok || addr == nil {
+		return
+	}
+	ip := addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	r.mu.Lock()
+	r.ip = ip
+	r.mu.Unlock()
+}
+
+// dialContext opens a connection honoring the IP family in ctx and records
+// the remote address. Checkers that dial themselves should go through it.
+func dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := net.Dialer{KeepAlive: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, familyNetwork(ctx, network), addr)
+	if err == nil {
+		recordRemote(ctx, conn.RemoteAddr())
+	}
+	return conn, err
+}
+
+// checkFamily runs checker restricted to family and fills in the remote IP.
+func checkFamily(ctx context.Context, checker ports.Checker, service *domain.Service, family string) domain.CheckResult {
+	remote := &remoteAddr{}
+	ctx = context.WithValue(withIPFamily(ctx, family), remoteAddrKey{}, remote)
+
+	result := checker.Check(ctx, service)
+	if result.RemoteIP == "" {
+		result.RemoteIP = remote.get()
+	}
+	return result
+}
+
+// checkDualStack checks over IPv4 and IPv6 concurrently. The service passes
+// only if both do; failures are prefixed with their family.
+func checkDualStack(ctx context.Context, checker ports.Checker, service *domain.Service) domain.CheckResult {
+	families := []string{domain.IPFamilyV4, domain.IPFamilyV6}
+	results := make([]domain.CheckResult, len(families))
+
+	var wg sync.WaitGroup
+	for i, family := range families {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = checkFamily(ctx, checker, service, family)
+		}()
+	}
+	wg.Wait()
+
+	// Report the first failing family, or IPv4 when both pass, with the
+	// slowest latency and worst status of both
+	combined := results[0]
+	latency, status := combined.Latency, combined.Status
+	var failures []string
+	for i, r := range results {
+		combined.Families = append(combined.Families, domain.FamilyResult{
+			Family:       families[i],
+			RemoteIP:     r.RemoteIP,
+			Latency:      r.Latency,
+			Success:      r.Success,
+			FailureKind:  r.FailureKind,
+			ErrorMessage: r.ErrorMessage,
+		})
+		if !r.Success {
+			if len(failures) == 0 {
+				kept := combined.Families
+				combined = r
+				combined.Families = kept
+			}
+			failures = append(failures, families[i]+": "+r.ErrorMessage)
+		}
+		latency = max(latency, r.Latency)
+		if r.Status != "" {
+			status = domain.WorseStatus(status, r.Status)
+		}
+	}
+	combined.Latency, combined.Status = latency, status
+	if len(failures) > 0 {
+		combined.ErrorMessage = strings.Join(failures, "; ")
+	}
+	return combined
+}
@@ -0,0 +1,137 @@
+package pinger
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type GRPCChecker struct{}
+
+func NewGRPCChecker() *GRPCChecker {
+	return &GRPCChecker{}
+}
+
+// Check calls grpc.health.v1.Health/Check. SERVING is healthy, NOT_SERVING
+// fails the check and UNKNOWN is reported as such.
+func (c *GRPCChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	cfg := service.Config.GRPC
+	if cfg == nil {
+		cfg = &domain.GRPCConfig{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		serverName := cfg.ServerName
+		if serverName == "" {
+			serverName, _, _ = net.SplitHostPort(service.URL)
+		}
+		creds = credentials.NewTLS(&tls.Config{ServerName: serverName})
+	}
+
+	// gRPC dials with its own context, so route through the check context explicitly
+	checkCtx := ctx
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, familyNetwork(checkCtx, "tcp"), addr)
+		if err == nil {
+			recordRemote(checkCtx, conn.RemoteAddr())
+		}
+		return conn, err
+	}
+
+	conn, err := grpc.NewClient("passthrough:///"+service.URL, grpc.WithTransportCredentials(creds), grpc.WithContextDialer(dialer))
+	if err != nil {
+		return domain.CheckResult{
+			ServiceID:    service.ID,
+			CheckedAt:    start,
+			Success:      false,
+			FailureKind:  domain.FailureConfig,
+			ErrorMessage: "invalid gRPC target: " + err.Error(),
+		}
+	}
+	defer conn.Close()
+
+	if len(cfg.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(cfg.Metadata))
+	}
+
+	var p peer.Peer
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: cfg.Service}, grpc.Peer(&p))
+	latency := time.Since(start)
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+		Latency:   latency,
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		result.TLS = inspectCertificates(info.State.PeerCertificates, info.State.ServerName, nil)
+	}
+
+	if err != nil {
+		st := status.Convert(err)
+		result.FailureKind = grpcFailureKind(st)
+		if st.Code() == codes.DeadlineExceeded {
+			result.ErrorMessage = fmt.Sprintf("timeout after %s: %s", service.GetTimeout(), st.Message())
+		} else {
+			result.ErrorMessage = fmt.Sprintf("%s: %s", st.Code(), st.Message())
+		}
+		return result
+	}
+
+	switch resp.GetStatus() {
+	case healthpb.HealthCheckResponse_SERVING:
+		result.Success = true
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		result.FailureKind = domain.FailureUnhealthy
+		result.ErrorMessage = "NOT_SERVING"
+	default:
+		result.Success = true
+		result.Status = domain.StatusUnknown
+		result.ErrorMessage = resp.GetStatus().String()
+	}
+	return result
+}
+
+// grpcFailureKind classifies a failed health call. Transport errors only
+// survive as text in the status message, so Unavailable is split by message.
+func grpcFailureKind(st *status.Status) domain.FailureKind {
+	switch st.Code() {
+	case codes.DeadlineExceeded:
+		return domain.FailureTimeout
+	case codes.Unavailable:
+		msg := st.Message()
+		switch {
+		case strings.Contains(msg, "connection refused"):
+			return domain.FailureConnectRefused
+		case strings.Contains(msg, "tls:") || strings.Contains(msg, "x509:"):
+			return domain.FailureTLS
+		case strings.Contains(msg, "no such host") || strings.Contains(msg, "produced zero addresses"):
+			return domain.FailureDNS
+		default:
+			return domain.FailureConnect
+		}
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return domain.FailureConfig
+	default:
+		return domain.FailureProtocol
+	}
+}
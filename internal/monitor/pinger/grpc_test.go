@@ -0,0 +1,106 @@
+package pinger
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// startHealthServer serves grpc.health.v1 on a local port with the given
+// statuses. Calls must carry the authorization metadata "token".
+func startHealthServer(t *testing.T, statuses map[string]healthpb.HealthCheckResponse_ServingStatus) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requireToken := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "token" {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+		return handler(ctx, req)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(requireToken))
+	hs := health.NewServer()
+	for service, st := range statuses {
+		hs.SetServingStatus(service, st)
+	}
+	healthpb.RegisterHealthServer(server, hs)
+
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func TestGRPCCheckerAgainstLocalServer(t *testing.T) {
+	addr := startHealthServer(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":            healthpb.HealthCheckResponse_SERVING,
+		"app.Orders":  healthpb.HealthCheckResponse_SERVING,
+		"app.Billing": healthpb.HealthCheckResponse_NOT_SERVING,
+	})
+
+	// A port that was free a moment ago refuses connections
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	auth := map[string]string{"authorization": "token"}
+	tests := []struct {
+		name     string
+		target   string
+		service  string
+		metadata map[string]string
+		wantOK   bool
+		wantKind domain.FailureKind
+	}{
+		{name: "server serving", target: addr, metadata: auth, wantOK: true},
+		{name: "service serving", target: addr, service: "app.Orders", metadata: auth, wantOK: true},
+		{name: "service not serving", target: addr, service: "app.Billing", metadata: auth, wantKind: domain.FailureUnhealthy},
+		{name: "unknown service", target: addr, service: "app.Missing", metadata: auth, wantKind: domain.FailureProtocol},
+		{name: "missing metadata", target: addr, wantKind: domain.FailureConfig},
+		{name: "connection refused", target: closedAddr, metadata: auth, wantKind: domain.FailureConnectRefused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &domain.Service{
+				Name:     tt.name,
+				URL:      tt.target,
+				Type:     domain.ServiceTypeGRPC,
+				Interval: time.Minute,
+				Timeout:  2 * time.Second,
+				Config: domain.CheckConfig{GRPC: &domain.GRPCConfig{
+					Service:  tt.service,
+					Metadata: tt.metadata,
+				}},
+			}
+			if err := service.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			result := NewGRPCChecker().Check(context.Background(), service)
+			if result.Success != tt.wantOK {
+				t.Fatalf("success = %v, want %v (%s)", result.Success, tt.wantOK, result.ErrorMessage)
+			}
+			if result.FailureKind != tt.wantKind {
+				t.Errorf("failure kind = %q, want %q (%s)", result.FailureKind, tt.wantKind, result.ErrorMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package pinger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+// HeartbeatChecker evaluates push services. It doesn't contact anything;
+// it verifies that the job's last heartbeat arrived within interval + grace.
+type HeartbeatChecker struct {
+	heartbeats ports.HeartbeatRepository
+}
+
+func NewHeartbeatChecker(heartbeats ports.HeartbeatRepository) *HeartbeatChecker {
+	return &HeartbeatChecker{
+		heartbeats: heartbeats,
+	}
+}
+
+func (c *HeartbeatChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	now := time.Now()
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: now,
+		Success:   true,
+	}
+
+	hb, err := c.heartbeats.GetLatest(ctx, service.ID)
+	if err != nil {
+		// Can't tell whether the job ran, so don't report it down
+		result.Status = domain.StatusUnknown
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	window := service.Interval + service.Config.Push.Grace()
+
+	if hb == nil {
+		if now.Sub(service.CreatedAt) <= window {
+			result.Status = domain.StatusUnknown
+			result.ErrorMessage = "awaiting first heartbeat"
+			return result
+		}
+		result.Success = false
+		result.FailureKind = domain.FailureHeartbeatMissed
+		result.ErrorMessage = fmt.Sprintf("no heartbeat received since creation %s ago", now.Sub(service.CreatedAt).Round(time.Second))
+		return result
+	}
+
+	if age := now.Sub(hb.ReceivedAt); age > window {
+		result.Success = false
+		result.FailureKind = domain.FailureHeartbeatMissed
+		result.ErrorMessage = fmt.Sprintf("last heartbeat %s ago, expected every %s", age.Round(time.Second), window)
+		return result
+	}
+
+	if hb.Status == domain.HeartbeatFail {
+		result.Success = false
+		result.FailureKind = domain.FailureUnhealthy
+		result.ErrorMessage = "job reported failure"
+		if hb.Message != "" {
+			result.ErrorMessage += ": " + hb.Message
+		}
+	}
+	return result
+}
@@ -2,39 +2,99 @@ package pinger
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"time"
 
 	"github.com/umutaraz/pulseguard/internal/core/domain"
 )
 
+// maxBodySize caps how much of the body is read when the service
+// sets no explicit max_response_size.
+const maxBodySize = 1 << 20
+
 type HTTPPinger struct {
-	client *http.Client
+	transports     *transportCache
+	securityPolicy domain.SecurityPolicy // Global policy of the security header audit
 }
 
-func NewHTTPPinger(timeout time.Duration) *HTTPPinger {
+// NewHTTPPinger returns an HTTP checker. Requests are bounded by the
+// service timeout through the check context rather than a client timeout.
+func NewHTTPPinger() *HTTPPinger {
 	return &HTTPPinger{
-		client: &http.Client{
-			Timeout: timeout,
-		},
+		transports:     newTransportCache(),
+		securityPolicy: domain.DefaultSecurityPolicy(),
+	}
+}
+
+func (p *HTTPPinger) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	cfg := service.Config.HTTP
+	result, resp := p.execute(ctx, service, service.URL, cfg)
+	if resp != nil && cfg != nil && cfg.SecurityAudit != nil {
+		p.auditSecurity(&result, resp, cfg.SecurityAudit)
+	}
+	if result.Success && resp != nil && cfg != nil && cfg.ContentWatch != nil {
+		if err := watchContent(&result, resp.body, cfg.ContentWatch); err != nil {
+			slog.Warn("Content watch skipped", "service", service.Name, "error", err)
+		}
 	}
+	return result
+}
+
+// httpResponse carries the parts of a response later scenario steps extract from.
+type httpResponse struct {
+	header    http.Header
+	body      []byte
+	truncated bool // body was cut at maxBodySize
 }
 
-func (p *HTTPPinger) Ping(ctx context.Context, service *domain.Service) domain.CheckResult {
+// execute sends the request described by cfg to url and judges the response.
+// The returned response is nil when none was received.
+func (p *HTTPPinger) execute(ctx context.Context, service *domain.Service, url string, cfg *domain.HTTPConfig) (domain.CheckResult, *httpResponse) {
 	start := time.Now()
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", service.URL, nil)
+
+	req, err := newHTTPRequest(ctx, url, cfg)
 	if err != nil {
 		return domain.CheckResult{
 			ServiceID:    service.ID,
 			CheckedAt:    start,
 			Success:      false,
+			FailureKind:  domain.FailureConfig,
 			ErrorMessage: "invalid url request creation failed: " + err.Error(),
 			Latency:      0,
-		}
+		}, nil
+	}
+
+	var transportCfg *domain.TransportConfig
+	if cfg != nil {
+		transportCfg = cfg.Transport
+	}
+	clients, err := p.transports.get(transportCfg)
+	if err != nil {
+		return domain.CheckResult{
+			ServiceID:    service.ID,
+			CheckedAt:    start,
+			Success:      false,
+			FailureKind:  domain.FailureConfig,
+			ErrorMessage: "invalid transport settings: " + err.Error(),
+		}, nil
+	}
+	client := clients.client
+	if !cfg.ShouldFollowRedirects() {
+		client = clients.noRedirectClient
 	}
 
-	resp, err := p.client.Do(req)
+	tracer := &httpTracer{}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), tracer.clientTrace()))
+
+	resp, err := client.Do(req)
 	latency := time.Since(start)
 
 	if err != nil {
@@ -42,24 +102,103 @@ func (p *HTTPPinger) Ping(ctx context.Context, service *domain.Service) domain.C
 			ServiceID:    service.ID,
 			CheckedAt:    start,
 			Success:      false,
-			ErrorMessage: err.Error(),
+			FailureKind:  classifyError(err),
+			ErrorMessage: errorMessage(err, service),
 			Latency:      latency,
-		}
+			TLS:          tlsInfoFromError(err, req.URL.Hostname(), clients.roots),
+			Timings:      tracer.finish(time.Now()),
+		}, nil
 	}
 	defer resp.Body.Close()
 
-	success := resp.StatusCode >= 200 && resp.StatusCode < 400
+	success := cfg.AcceptsStatus(resp.StatusCode)
 	var errMsg string
+	var kind domain.FailureKind
 	if !success {
 		errMsg = http.StatusText(resp.StatusCode)
+		kind = domain.FailureHTTPStatus
 	}
 
-	return domain.CheckResult{
+	result := domain.CheckResult{
 		ServiceID:    service.ID,
 		CheckedAt:    start,
 		StatusCode:   resp.StatusCode,
 		Latency:      latency,
 		Success:      success,
+		FailureKind:  kind,
 		ErrorMessage: errMsg,
 	}
+	if resp.TLS != nil {
+		result.TLS = inspectCertificates(resp.TLS.PeerCertificates, resp.Request.URL.Hostname(), clients.roots)
+	}
+
+	// The body is always consumed so the transfer phase is measured
+	body, truncated, err := readBody(resp, cfg)
+	result.Timings = tracer.finish(time.Now())
+	if err == nil && success && cfg != nil {
+		err = withKind(domain.FailureAssertion, evaluateAssertions(cfg.Assertions, resp.Header, body))
+	}
+	if err != nil && result.Success {
+		result.Success = false
+		result.FailureKind = classifyError(err)
+		result.ErrorMessage = errorMessage(err, service)
+	}
+
+	return result, &httpResponse{header: resp.Header, body: body, truncated: truncated}
+}
+
+// newHTTPRequest builds the probe request described by cfg; a nil cfg yields a bare GET.
+func newHTTPRequest(ctx context.Context, url string, cfg *domain.HTTPConfig) (*http.Request, error) {
+	var body io.Reader
+	if cfg != nil && cfg.Body != "" {
+		body = strings.NewReader(cfg.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, cfg.GetMethod(), url, body)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return req, nil
+	}
+
+	for k, v := range cfg.Headers {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+
+	if auth := cfg.Auth; auth != nil {
+		switch auth.Type {
+		case domain.AuthBasic:
+			req.SetBasicAuth(auth.Username, auth.Password)
+		case domain.AuthBearer:
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		}
+	}
+	return req, nil
+}
+
+// readBody reads the response body up to max_response_size, or
+// maxBodySize when the service sets no limit. Bodies over maxBodySize are
+// cut and reported as truncated.
+func readBody(resp *http.Response, cfg *domain.HTTPConfig) ([]byte, bool, error) {
+	limit := int64(maxBodySize)
+	if cfg != nil && cfg.MaxResponseSize > 0 {
+		limit = cfg.MaxResponseSize
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, false, withKind(domain.FailureBodyRead, fmt.Errorf("failed to read response body: %w", err))
+	}
+	if int64(len(body)) > limit {
+		if cfg != nil && cfg.MaxResponseSize > 0 {
+			return nil, false, withKind(domain.FailureAssertion, fmt.Errorf("assertion failed: response size exceeds max_response_size of %d bytes", cfg.MaxResponseSize))
+		}
+		return body[:limit], true, nil
+	}
+	return body, false, nil
 }
@@ -0,0 +1,73 @@
+package pinger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupJSONPath resolves a simple dotted path ($.a.b[0].c or a.b.0.c) in a
+// decoded JSON document. Filters and wildcards are intentionally not supported.
+func lookupJSONPath(doc any, path string) (any, error) {
+	segments, err := splitJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := doc
+	for _, seg := range segments {
+		switch node := current.(type) {
+		case map[string]any:
+			v, ok := node[seg]
+			if !ok {
+				return nil, fmt.Errorf("path %s: key %q not found", path, seg)
+			}
+			current = v
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("path %s: index %q out of range", path, seg)
+			}
+			current = node[idx]
+		default:
+			return nil, fmt.Errorf("path %s: cannot descend into %q", path, seg)
+		}
+	}
+	return current, nil
+}
+
+func splitJSONPath(path string) ([]string, error) {
+	p := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	p = strings.ReplaceAll(p, "[", ".")
+	p = strings.ReplaceAll(p, "]", "")
+
+	var segments []string
+	for _, seg := range strings.Split(p, ".") {
+		seg = strings.Trim(seg, `'"`)
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	if len(segments) == 0 && p != "" {
+		return nil, fmt.Errorf("invalid JSON path %q", path)
+	}
+	return segments, nil
+}
+
+// jsonValueString renders a JSON scalar the way users write it in assertions.
+func jsonValueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+}
@@ -0,0 +1,96 @@
+package pinger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type PostgresChecker struct{}
+
+func NewPostgresChecker() *PostgresChecker {
+	return &PostgresChecker{}
+}
+
+// Check connects with the service DSN and runs the probe query inside a
+// read-only transaction. Latency covers both the connection and the query.
+func (c *PostgresChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	cfg := service.Config.Postgres
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+	}
+
+	value, err := c.probe(ctx, service.URL, cfg.GetQuery())
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.FailureKind = postgresFailureKind(err)
+		result.ErrorMessage = errorMessage(err, service)
+		return result
+	}
+
+	if cfg != nil && cfg.Expected != "" && value != cfg.Expected {
+		result.FailureKind = domain.FailureAssertion
+		result.ErrorMessage = fmt.Sprintf("query returned %q, expected %q", value, cfg.Expected)
+		return result
+	}
+	result.Success = true
+	return result
+}
+
+// probe returns the first column of the first row returned by query, as text.
+func (c *PostgresChecker) probe(ctx context.Context, dsn, query string) (string, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		// The parse error may quote the DSN, password included
+		return "", withKind(domain.FailureConfig, errors.New("invalid DSN"))
+	}
+
+	connConfig.DialFunc = dialContext
+
+	conn, err := pgx.ConnectConfig(ctx, connConfig)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close(context.Background())
+
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(context.Background())
+
+	var value any
+	if err := tx.QueryRow(ctx, query).Scan(&value); err != nil {
+		return "", fmt.Errorf("probe query failed: %w", err)
+	}
+	if value == nil {
+		return "NULL", nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// postgresFailureKind treats errors reported by the server as the database
+// being unhealthy, except for authentication failures which are configuration.
+func postgresFailureKind(err error) domain.FailureKind {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return classifyError(err)
+	}
+	if strings.HasPrefix(pgErr.Code, "28") { // Invalid authorization specification
+		return domain.FailureConfig
+	}
+	return domain.FailureUnhealthy
+}
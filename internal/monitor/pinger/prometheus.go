@@ -0,0 +1,166 @@
+package pinger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// PrometheusChecker scrapes a text-format metrics endpoint and evaluates the
+// service's rules against it. The scrape itself is a regular HTTP check.
+type PrometheusChecker struct {
+	http *HTTPPinger
+}
+
+func NewPrometheusChecker() *PrometheusChecker {
+	return &PrometheusChecker{
+		http: NewHTTPPinger(),
+	}
+}
+
+func (c *PrometheusChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	result, resp := c.http.execute(ctx, service, service.URL, service.Config.HTTP)
+	if !result.Success || resp == nil {
+		return result
+	}
+
+	// A cut exposition ends mid-sample and would be evaluated on wrong values
+	if resp.truncated {
+		result.Success = false
+		result.FailureKind = domain.FailureBodyRead
+		result.ErrorMessage = fmt.Sprintf("metrics exposition exceeds %d bytes, raise http.max_response_size to scrape it", maxBodySize)
+		return result
+	}
+
+	var rules []string
+	if cfg := service.Config.Prometheus; cfg != nil {
+		rules = cfg.Rules
+	}
+
+	series := parseExposition(resp.body)
+	status := domain.StatusHealthy
+	var fired []string
+	for _, raw := range rules {
+		rule, err := domain.ParseMetricRule(raw)
+		if err != nil {
+			result.Success = false
+			result.FailureKind = domain.FailureConfig
+			result.ErrorMessage = err.Error()
+			return result
+		}
+		for _, s := range series {
+			if rule.Matches(s.name, s.labels) && rule.Compare(s.value) {
+				status = domain.WorseStatus(status, rule.Status)
+				fired = append(fired, fmt.Sprintf("%s = %s (%s %s)", s.raw, formatSample(s.value), rule.Operator, formatSample(rule.Value)))
+				break
+			}
+		}
+	}
+
+	if status == domain.StatusDown {
+		result.Success = false
+		result.FailureKind = domain.FailureUnhealthy
+	} else {
+		result.Status = status
+	}
+	if len(fired) > 0 {
+		result.ErrorMessage = strings.Join(fired, "; ")
+	}
+	return result
+}
+
+// sample is one series of a scrape.
+type sample struct {
+	raw    string // Series as exposed, name and labels
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// parseExposition reads samples from the Prometheus text format. Comments,
+// timestamps and malformed lines are ignored.
+func parseExposition(body []byte) []sample {
+	var samples []sample
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBodySize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		end := seriesEnd(line)
+		if end < 0 {
+			continue
+		}
+		fields := strings.Fields(line[end:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := parseSampleValue(fields[0])
+		if err != nil {
+			continue
+		}
+
+		raw := strings.TrimSpace(line[:end])
+		name, labels, err := domain.ParseSelector(raw)
+		if err != nil {
+			continue
+		}
+		samples = append(samples, sample{raw: raw, name: name, labels: labels, value: value})
+	}
+	return samples
+}
+
+// seriesEnd returns the index just past the series name and labels, honoring
+// quoted label values, or -1 for a malformed line.
+func seriesEnd(line string) int {
+	inLabels, quoted := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quoted = false
+			}
+		case inLabels:
+			if c == '"' {
+				quoted = true
+			} else if c == '}' {
+				return i + 1
+			}
+		case c == '{':
+			inLabels = true
+		case c == ' ' || c == '\t':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseSampleValue(s string) (float64, error) {
+	switch s {
+	case "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func formatSample(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
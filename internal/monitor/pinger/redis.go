@@ -0,0 +1,109 @@
+package pinger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type RedisChecker struct{}
+
+func NewRedisChecker() *RedisChecker {
+	return &RedisChecker{}
+}
+
+// Check sends PING, or GET for the configured key, over a fresh connection.
+func (c *RedisChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	cfg := service.Config.Redis
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+	}
+
+	opts, err := redisOptions(service.URL)
+	if err != nil {
+		result.FailureKind = domain.FailureConfig
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	checkCtx := ctx
+	opts.Dialer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, familyNetwork(checkCtx, network), addr)
+		if err == nil {
+			recordRemote(checkCtx, conn.RemoteAddr())
+		}
+		return conn, err
+	}
+	client := redis.NewClient(opts)
+	defer client.Close()
+
+	var value string
+	if cfg != nil && cfg.Key != "" {
+		value, err = client.Get(ctx, cfg.Key).Result()
+		if errors.Is(err, redis.Nil) {
+			err = withKind(domain.FailureAssertion, fmt.Errorf("key %q not found", cfg.Key))
+		}
+	} else {
+		value, err = client.Ping(ctx).Result()
+	}
+	result.Latency = time.Since(start)
+
+	if err != nil {
+		result.FailureKind = redisFailureKind(err)
+		result.ErrorMessage = errorMessage(err, service)
+		return result
+	}
+
+	if cfg != nil && cfg.Key != "" && cfg.Expected != "" && value != cfg.Expected {
+		result.FailureKind = domain.FailureAssertion
+		result.ErrorMessage = fmt.Sprintf("key %q is %q, expected %q", cfg.Key, value, cfg.Expected)
+		return result
+	}
+	result.Success = true
+	return result
+}
+
+// redisOptions builds single-connection client options for target, which is
+// either host:port or a redis:// URL. Timeouts come from the check context.
+func redisOptions(target string) (*redis.Options, error) {
+	opts := &redis.Options{Addr: target}
+	if strings.Contains(target, "://") {
+		parsed, err := redis.ParseURL(target)
+		if err != nil {
+			return nil, errors.New("invalid Redis URL")
+		}
+		opts = parsed
+	}
+	opts.PoolSize = 1
+	opts.MaxRetries = -1
+	opts.ContextTimeoutEnabled = true
+	opts.DisableIdentity = true // Skip CLIENT SETINFO on every probe
+	return opts, nil
+}
+
+// redisFailureKind treats error replies as the server being unhealthy, e.g.
+// LOADING or MASTERDOWN, except for authentication errors.
+func redisFailureKind(err error) domain.FailureKind {
+	var replyErr redis.Error
+	if !errors.As(err, &replyErr) || errors.Is(err, redis.Nil) {
+		return classifyError(err)
+	}
+	msg := replyErr.Error()
+	if strings.HasPrefix(msg, "NOAUTH") || strings.HasPrefix(msg, "WRONGPASS") {
+		return domain.FailureConfig
+	}
+	return domain.FailureUnhealthy
+}
@@ -0,0 +1,71 @@
+package pinger
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+// Registry dispatches checks to the Checker registered for a service's Type.
+// It implements ports.Checker itself, so the engine only ever talks to one checker.
+type Registry struct {
+	mu       sync.RWMutex
+	checkers map[string]ports.Checker
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		checkers: make(map[string]ports.Checker),
+	}
+}
+
+// NewDefaultRegistry returns a registry with all built-in checkers registered.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(domain.ServiceTypeHTTP, NewHTTPPinger())
+	r.Register(domain.ServiceTypeTCP, NewTCPChecker())
+	r.Register(domain.ServiceTypeDNS, NewDNSChecker())
+	r.Register(domain.ServiceTypeTLS, NewTLSChecker())
+	r.Register(domain.ServiceTypeScenario, NewScenarioChecker())
+	r.Register(domain.ServiceTypeGRPC, NewGRPCChecker())
+	r.Register(domain.ServiceTypePostgres, NewPostgresChecker())
+	r.Register(domain.ServiceTypeRedis, NewRedisChecker())
+	r.Register(domain.ServiceTypePrometheus, NewPrometheusChecker())
+	r.Register(domain.ServiceTypeWS, NewWSChecker())
+	return r
+}
+
+// Register adds or replaces the checker for the given service type.
+func (r *Registry) Register(serviceType string, checker ports.Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers[serviceType] = checker
+}
+
+func (r *Registry) Get(serviceType string) (ports.Checker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	checker, ok := r.checkers[serviceType]
+	return checker, ok
+}
+
+func (r *Registry) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	checker, ok := r.Get(service.Type)
+	if !ok {
+		return domain.CheckResult{
+			ServiceID:    service.ID,
+			CheckedAt:    time.Now(),
+			Success:      false,
+			FailureKind:  domain.FailureConfig,
+			ErrorMessage: fmt.Sprintf("no checker registered for service type %q", service.Type),
+		}
+	}
+	if service.Config.IPFamily == domain.IPFamilyDual {
+		return checkDualStack(ctx, checker, service)
+	}
+	return checkFamily(ctx, checker, service, service.Config.IPFamily)
+}
@@ -0,0 +1,104 @@
+package pinger
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/monitor/scheduler"
+)
+
+// fakeChecker answers every check with its own name and counts the calls.
+type fakeChecker struct {
+	name  string
+	calls int
+}
+
+func (c *fakeChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	c.calls++
+	return domain.CheckResult{ServiceID: service.ID, CheckedAt: time.Now(), Success: true, ErrorMessage: c.name}
+}
+
+func TestRegistryDispatchesByType(t *testing.T) {
+	const fakeType = "FAKE"
+
+	registry := NewDefaultRegistry()
+	fake := &fakeChecker{name: "fake"}
+	registry.Register(fakeType, fake)
+
+	service := domain.NewService("custom", "fake://target", fakeType, time.Minute, false)
+	result := registry.Check(context.Background(), service)
+	if !result.Success || result.ErrorMessage != "fake" || result.ServiceID != service.ID {
+		t.Fatalf("result = %+v, want the fake checker's", result)
+	}
+	if fake.calls != 1 {
+		t.Fatalf("fake checker called %d times, want 1", fake.calls)
+	}
+
+	// Registering again replaces the checker for the type
+	replacement := &fakeChecker{name: "replacement"}
+	registry.Register(fakeType, replacement)
+	if result := registry.Check(context.Background(), service); result.ErrorMessage != "replacement" {
+		t.Errorf("result from %q after replacing the checker", result.ErrorMessage)
+	}
+	if fake.calls != 1 {
+		t.Errorf("replaced checker still called")
+	}
+
+	if _, ok := registry.Get(domain.ServiceTypeHTTP); !ok {
+		t.Error("built-in HTTP checker missing")
+	}
+}
+
+// TestEngineRunsRegisteredType checks that a type registered from outside
+// the engine is scheduled and checked like a built-in one.
+func TestEngineRunsRegisteredType(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("FAKE", &fakeChecker{name: "fake"})
+
+	engine := scheduler.NewMonitoringEngine(nil, registry, config.SchedulerConfig{Workers: 1})
+	defer engine.Shutdown()
+
+	results := make(chan domain.CheckResult, 1)
+	engine.SetResultHandler(func(result domain.CheckResult) {
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	service := domain.NewService("custom", "fake://target", "FAKE", time.Minute, false)
+	engine.StartMonitorForService(service)
+
+	select {
+	case result := <-results:
+		if result.ServiceID != service.ID || result.ErrorMessage != "fake" {
+			t.Errorf("result = %+v, want the fake checker's", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("engine never checked the service")
+	}
+}
+
+func TestRegistryRejectsUnknownTypes(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("FAKE", &fakeChecker{})
+
+	service := domain.NewService("unknown", "x://target", "UNKNOWN", time.Minute, false)
+	result := registry.Check(context.Background(), service)
+	if result.Success {
+		t.Fatal("check of an unregistered type succeeded")
+	}
+	if result.FailureKind != domain.FailureConfig {
+		t.Errorf("failure kind = %q, want %q", result.FailureKind, domain.FailureConfig)
+	}
+	if !strings.Contains(result.ErrorMessage, `"UNKNOWN"`) || result.ServiceID != service.ID {
+		t.Errorf("result = %+v, want it to name the type", result)
+	}
+	if _, ok := registry.Get("UNKNOWN"); ok {
+		t.Error("Get found a checker for an unregistered type")
+	}
+}
@@ -0,0 +1,223 @@
+package pinger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+var scenarioVar = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// ScenarioChecker runs the steps of a SCENARIO service in order, feeding
+// values extracted from one response into the requests that follow.
+type ScenarioChecker struct {
+	http *HTTPPinger
+}
+
+func NewScenarioChecker() *ScenarioChecker {
+	return &ScenarioChecker{
+		http: NewHTTPPinger(),
+	}
+}
+
+// Check runs every step within a single service timeout and stops at the
+// first failing step. Latency is the sum of the executed steps.
+func (c *ScenarioChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+		Success:   true,
+	}
+	if service.Config.Scenario == nil {
+		result.Success = false
+		result.FailureKind = domain.FailureConfig
+		result.ErrorMessage = "scenario has no steps"
+		return result
+	}
+
+	base, err := url.Parse(service.URL)
+	if err != nil {
+		result.Success = false
+		result.FailureKind = domain.FailureConfig
+		result.ErrorMessage = "invalid base url: " + err.Error()
+		return result
+	}
+
+	vars := make(map[string]string)
+	for i, step := range service.Config.Scenario.Steps {
+		stepResult, resp := c.runStep(ctx, service, base, step, vars)
+		if stepResult.Success && resp != nil {
+			if err := extractVars(step.Extract, resp, vars); err != nil {
+				stepResult.Success = false
+				stepResult.FailureKind = domain.FailureAssertion
+				stepResult.ErrorMessage = err.Error()
+			}
+		}
+
+		result.Latency += stepResult.Latency
+		result.StatusCode = stepResult.StatusCode
+		result.Steps = append(result.Steps, domain.StepResult{
+			Name:         step.Name,
+			StatusCode:   stepResult.StatusCode,
+			Latency:      stepResult.Latency,
+			Success:      stepResult.Success,
+			FailureKind:  stepResult.FailureKind,
+			ErrorMessage: stepResult.ErrorMessage,
+		})
+
+		if !stepResult.Success {
+			result.Success = false
+			result.FailureKind = stepResult.FailureKind
+			result.ErrorMessage = fmt.Sprintf("step %d (%s): %s", i+1, step.Name, stepResult.ErrorMessage)
+			break
+		}
+	}
+	return result
+}
+
+// runStep substitutes variables into the step and executes it as an HTTP check.
+func (c *ScenarioChecker) runStep(ctx context.Context, service *domain.Service, base *url.URL, step domain.ScenarioStep, vars map[string]string) (domain.CheckResult, *httpResponse) {
+	cfg, target, err := expandStep(step, base, vars)
+	if err != nil {
+		return domain.CheckResult{
+			ServiceID:    service.ID,
+			CheckedAt:    time.Now(),
+			Success:      false,
+			FailureKind:  domain.FailureConfig,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+	return c.http.execute(ctx, service, target, cfg)
+}
+
+// expandStep returns the step's request settings and absolute URL with all
+// {{var}} references replaced.
+func expandStep(step domain.ScenarioStep, base *url.URL, vars map[string]string) (*domain.HTTPConfig, string, error) {
+	var err error
+	expand := func(s string) string {
+		if err != nil {
+			return s
+		}
+		var out string
+		out, err = substituteVars(s, vars)
+		return out
+	}
+
+	cfg := step.HTTPConfig
+	rawURL := expand(step.URL)
+	cfg.Body = expand(cfg.Body)
+
+	if len(cfg.Headers) > 0 {
+		headers := make(map[string]string, len(cfg.Headers))
+		for k, v := range cfg.Headers {
+			headers[k] = expand(v)
+		}
+		cfg.Headers = headers
+	}
+
+	if cfg.Auth != nil {
+		auth := *cfg.Auth
+		auth.Username = expand(auth.Username)
+		auth.Password = expand(auth.Password)
+		auth.Token = expand(auth.Token)
+		cfg.Auth = &auth
+	}
+
+	if len(cfg.Assertions) > 0 {
+		assertions := make([]domain.Assertion, len(cfg.Assertions))
+		for i, a := range cfg.Assertions {
+			a.Value = expand(a.Value)
+			assertions[i] = a
+		}
+		cfg.Assertions = assertions
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid step url: %w", err)
+	}
+	return &cfg, base.ResolveReference(ref).String(), nil
+}
+
+// substituteVars replaces {{name}} references; an undefined variable is an error.
+func substituteVars(s string, vars map[string]string) (string, error) {
+	var missing string
+	out := scenarioVar.ReplaceAllStringFunc(s, func(ref string) string {
+		name := scenarioVar.FindStringSubmatch(ref)[1]
+		v, ok := vars[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return v
+	})
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable %q", missing)
+	}
+	return out, nil
+}
+
+// extractVars stores the values described by extractions from resp into vars.
+func extractVars(extractions []domain.Extraction, resp *httpResponse, vars map[string]string) error {
+	var doc any
+	var docErr error
+	docParsed := false
+
+	for _, ex := range extractions {
+		switch ex.Source {
+		case domain.ExtractJSONPath:
+			if !docParsed {
+				docErr = json.Unmarshal(resp.body, &doc)
+				docParsed = true
+			}
+			if docErr != nil {
+				return fmt.Errorf("extract %s: response body is not valid JSON", ex.Var)
+			}
+			v, err := lookupJSONPath(doc, ex.Expr)
+			if err != nil {
+				return fmt.Errorf("extract %s: %w", ex.Var, err)
+			}
+			vars[ex.Var] = jsonValueString(v)
+
+		case domain.ExtractRegex:
+			re, err := regexp.Compile(ex.Expr)
+			if err != nil {
+				return fmt.Errorf("extract %s: %w", ex.Var, err)
+			}
+			m := re.FindSubmatch(resp.body)
+			if m == nil {
+				return fmt.Errorf("extract %s: no match for %q", ex.Var, ex.Expr)
+			}
+			if len(m) > 1 {
+				vars[ex.Var] = string(m[1])
+			} else {
+				vars[ex.Var] = string(m[0])
+			}
+
+		case domain.ExtractHeader:
+			v := resp.header.Get(ex.Expr)
+			if v == "" {
+				return fmt.Errorf("extract %s: header %s not present", ex.Var, ex.Expr)
+			}
+			vars[ex.Var] = v
+
+		default:
+			return errors.New("unsupported extraction source " + ex.Source)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package pinger
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// WithSecurityPolicy sets the global policy of the security header audit.
+func (p *HTTPPinger) WithSecurityPolicy(cfg config.SecurityConfig) *HTTPPinger {
+	p.securityPolicy = domain.SecurityPolicy{
+		HSTS:               cfg.HSTS,
+		HSTSMinMaxAge:      cfg.HSTSMinMaxAge,
+		CSP:                cfg.CSP,
+		ContentTypeOptions: cfg.ContentTypeOptions,
+		FrameOptions:       cfg.FrameOptions,
+		SecureCookies:      cfg.SecureCookies,
+		HTTPOnlyCookies:    cfg.HTTPOnlyCookies,
+	}
+	return p
+}
+
+// auditSecurity audits resp against the service's policy, or the global one,
+// and downgrades the result to WARNING when the score is below min_score.
+func (p *HTTPPinger) auditSecurity(result *domain.CheckResult, resp *httpResponse, cfg *domain.SecurityAuditConfig) {
+	policy := p.securityPolicy
+	if cfg.Policy != nil {
+		policy = *cfg.Policy
+	}
+
+	audit := auditSecurityHeaders(resp.header, result.TLS != nil, policy)
+	result.Security = audit
+	if audit.Score < cfg.MinScore {
+		result.Status = domain.WorseStatus(result.Status, domain.StatusWarning)
+		if result.ErrorMessage == "" {
+			result.ErrorMessage = fmt.Sprintf("security score %d below %d", audit.Score, cfg.MinScore)
+		}
+	}
+}
+
+// auditSecurityHeaders scores header against policy. Every enabled rule weighs
+// the same; a policy without rules scores 100.
+func auditSecurityHeaders(header http.Header, https bool, policy domain.SecurityPolicy) *domain.SecurityAudit {
+	audit := &domain.SecurityAudit{}
+	rules, passed := 0, 0
+	check := func(enabled bool, rule string, message string) {
+		if !enabled {
+			return
+		}
+		rules++
+		if message == "" {
+			passed++
+			return
+		}
+		audit.Findings = append(audit.Findings, domain.SecurityFinding{Rule: rule, Message: message})
+	}
+
+	csp := header.Get("Content-Security-Policy")
+	cookies := (&http.Response{Header: header}).Cookies()
+
+	check(policy.HSTS, "hsts", auditHSTS(header, https, policy.HSTSMinMaxAge))
+	check(policy.CSP, "csp", auditCSP(header))
+	check(policy.ContentTypeOptions, "content_type_options", auditContentTypeOptions(header))
+	check(policy.FrameOptions, "frame_options", auditFrameOptions(header, csp))
+	check(policy.SecureCookies, "secure_cookies", auditCookies(cookies, "Secure", func(c *http.Cookie) bool { return c.Secure }))
+	check(policy.HTTPOnlyCookies, "httponly_cookies", auditCookies(cookies, "HttpOnly", func(c *http.Cookie) bool { return c.HttpOnly }))
+
+	audit.Score = 100
+	if rules > 0 {
+		audit.Score = passed * 100 / rules
+	}
+	return audit
+}
+
+// The audit* helpers return an empty string when the rule passes.
+
+func auditHSTS(header http.Header, https bool, minMaxAge int64) string {
+	if !https {
+		return "response served over plain HTTP, HSTS cannot apply"
+	}
+	value := header.Get("Strict-Transport-Security")
+	if value == "" {
+		return "Strict-Transport-Security header missing"
+	}
+	for _, directive := range strings.Split(value, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		maxAge, err := strconv.ParseInt(strings.Trim(strings.TrimSpace(arg), `"`), 10, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid HSTS max-age %q", arg)
+		}
+		if maxAge < minMaxAge {
+			return fmt.Sprintf("HSTS max-age %d is below %d", maxAge, minMaxAge)
+		}
+		return ""
+	}
+	return "Strict-Transport-Security has no max-age"
+}
+
+func auditCSP(header http.Header) string {
+	if header.Get("Content-Security-Policy") != "" {
+		return ""
+	}
+	if header.Get("Content-Security-Policy-Report-Only") != "" {
+		return "Content-Security-Policy is only set in report-only mode"
+	}
+	return "Content-Security-Policy header missing"
+}
+
+func auditContentTypeOptions(header http.Header) string {
+	value := header.Get("X-Content-Type-Options")
+	switch {
+	case value == "":
+		return "X-Content-Type-Options header missing"
+	case !strings.EqualFold(strings.TrimSpace(value), "nosniff"):
+		return fmt.Sprintf("X-Content-Type-Options is %q, want nosniff", value)
+	default:
+		return ""
+	}
+}
+
+// auditFrameOptions accepts X-Frame-Options DENY or SAMEORIGIN, or a CSP
+// frame-ancestors directive, which supersedes it.
+func auditFrameOptions(header http.Header, csp string) string {
+	if strings.Contains(strings.ToLower(csp), "frame-ancestors") {
+		return ""
+	}
+	value := strings.TrimSpace(header.Get("X-Frame-Options"))
+	switch {
+	case value == "":
+		return "X-Frame-Options header missing and CSP sets no frame-ancestors"
+	case !strings.EqualFold(value, "DENY") && !strings.EqualFold(value, "SAMEORIGIN"):
+		return fmt.Sprintf("X-Frame-Options is %q, want DENY or SAMEORIGIN", value)
+	default:
+		return ""
+	}
+}
+
+// auditCookies lists the cookies lacking flag. A response without cookies passes.
+func auditCookies(cookies []*http.Cookie, flag string, has func(*http.Cookie) bool) string {
+	var missing []string
+	for _, c := range cookies {
+		if !has(c) {
+			missing = append(missing, c.Name)
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("cookies without %s: %s", flag, strings.Join(missing, ", "))
+}
@@ -0,0 +1,123 @@
+package pinger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// maxBannerSize caps how much of the response banner is read.
+const maxBannerSize = 4096
+
+type TCPChecker struct{}
+
+func NewTCPChecker() *TCPChecker {
+	return &TCPChecker{}
+}
+
+func (c *TCPChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	cfg := service.Config.TCP
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	conn, err := dialContext(ctx, "tcp", service.URL)
+	latency := time.Since(start)
+	if err != nil {
+		return domain.CheckResult{
+			ServiceID:    service.ID,
+			CheckedAt:    start,
+			Success:      false,
+			FailureKind:  classifyError(err),
+			ErrorMessage: errorMessage(err, service),
+			Latency:      latency,
+		}
+	}
+	defer conn.Close()
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+		Latency:   latency,
+		Success:   true,
+	}
+
+	if cfg == nil || (cfg.Send == "" && !cfg.ExpectsBanner()) {
+		return result
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if cfg.Send != "" {
+		if _, err := conn.Write([]byte(cfg.Send)); err != nil {
+			result.Success = false
+			result.FailureKind = classifyError(err)
+			result.ErrorMessage = "failed to send payload: " + errorMessage(err, service)
+			return result
+		}
+	}
+
+	if !cfg.ExpectsBanner() {
+		return result
+	}
+
+	if err := readBanner(conn, cfg); err != nil {
+		result.Success = false
+		result.FailureKind = classifyError(err)
+		result.ErrorMessage = errorMessage(err, service)
+	}
+	return result
+}
+
+// readBanner reads from conn until the banner matches the expectation,
+// the connection closes, or maxBannerSize bytes have been received.
+func readBanner(conn net.Conn, cfg *domain.TCPConfig) error {
+	var re *regexp.Regexp
+	if cfg.ExpectRegex != "" {
+		var err error
+		if re, err = regexp.Compile(cfg.ExpectRegex); err != nil {
+			return withKind(domain.FailureConfig, fmt.Errorf("invalid expect_regex: %w", err))
+		}
+	}
+
+	matches := func(b []byte) bool {
+		if cfg.Expect != "" && !bytes.Contains(b, []byte(cfg.Expect)) {
+			return false
+		}
+		if re != nil && !re.Match(b) {
+			return false
+		}
+		return true
+	}
+
+	buf := make([]byte, 0, 512)
+	chunk := make([]byte, 512)
+	for len(buf) < maxBannerSize {
+		n, err := conn.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if matches(buf) {
+			return nil
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	return withKind(domain.FailureAssertion, fmt.Errorf("banner mismatch: got %q", truncate(strings.TrimSpace(string(buf)), 200)))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
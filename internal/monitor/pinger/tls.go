@@ -0,0 +1,121 @@
+package pinger
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type TLSChecker struct{}
+
+func NewTLSChecker() *TLSChecker {
+	return &TLSChecker{}
+}
+
+// Check performs a TLS handshake and reports the peer certificate. Verification
+// is done after the handshake so that expired or mismatched certificates are
+// still recorded; judging them is left to the analyzer.
+func (c *TLSChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	addr := service.URL
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+		addr = net.JoinHostPort(addr, "443")
+	}
+
+	serverName := host
+	if cfg := service.Config.TLS; cfg != nil && cfg.ServerName != "" {
+		serverName = cfg.ServerName
+	}
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			ServerName:         serverName,
+			InsecureSkipVerify: true, // Verified manually below
+		},
+	}
+	conn, err := dialer.DialContext(ctx, familyNetwork(ctx, "tcp"), addr)
+	latency := time.Since(start)
+	if err != nil {
+		return domain.CheckResult{
+			ServiceID:    service.ID,
+			CheckedAt:    start,
+			Success:      false,
+			FailureKind:  classifyError(err),
+			ErrorMessage: errorMessage(err, service),
+			Latency:      latency,
+		}
+	}
+	defer conn.Close()
+	recordRemote(ctx, conn.RemoteAddr())
+
+	state := conn.(*tls.Conn).ConnectionState()
+	return domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+		Latency:   latency,
+		Success:   true,
+		TLS:       inspectCertificates(state.PeerCertificates, serverName, nil),
+	}
+}
+
+// inspectCertificates verifies the chain against roots, or the system roots
+// when nil, and the leaf against serverName, and summarizes the leaf certificate.
+func inspectCertificates(certs []*x509.Certificate, serverName string, roots *x509.CertPool) *domain.TLSInfo {
+	if len(certs) == 0 {
+		return nil
+	}
+	leaf := certs[0]
+
+	info := &domain.TLSInfo{
+		Subject:       leaf.Subject.String(),
+		Issuer:        leaf.Issuer.String(),
+		SANs:          leaf.DNSNames,
+		NotBefore:     leaf.NotBefore,
+		NotAfter:      leaf.NotAfter,
+		DaysRemaining: int(time.Until(leaf.NotAfter).Hours() / 24),
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.SANs = append(info.SANs, ip.String())
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+		info.VerifyError = err.Error()
+	} else {
+		info.ChainValid = true
+	}
+
+	if err := leaf.VerifyHostname(serverName); err != nil {
+		if info.VerifyError == "" {
+			info.VerifyError = err.Error()
+		}
+	} else {
+		info.HostnameMatch = true
+	}
+
+	return info
+}
+
+// tlsInfoFromError extracts the peer certificate from a failed verification,
+// so HTTPS checks still report what the server presented.
+func tlsInfoFromError(err error, serverName string, roots *x509.CertPool) *domain.TLSInfo {
+	var verr *tls.CertificateVerificationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	return inspectCertificates(verr.UnverifiedCertificates, serverName, roots)
+}
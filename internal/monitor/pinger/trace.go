@@ -0,0 +1,82 @@
+package pinger
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// httpTracer collects phase timestamps of a single HTTP request. With
+// redirects, the phases of the last hop win.
+type httpTracer struct {
+	mu           sync.Mutex
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	timings      domain.HTTPTimings
+}
+
+func (t *httpTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dnsStart = time.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.timings.DNS = time.Since(t.dnsStart)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			t.connectStart = time.Now()
+			t.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			t.timings.Connect = time.Since(t.connectStart)
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStart = time.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.timings.TLS = time.Since(t.tlsStart)
+			t.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			t.wroteRequest = time.Now()
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.firstByte = time.Now()
+			t.mu.Unlock()
+		},
+	}
+}
+
+// finish completes the breakdown once the body has been consumed at bodyDone.
+func (t *httpTracer) finish(bodyDone time.Time) *domain.HTTPTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := t.timings
+	if !t.wroteRequest.IsZero() && !t.firstByte.IsZero() {
+		timings.TTFB = t.firstByte.Sub(t.wroteRequest)
+	}
+	if !t.firstByte.IsZero() && bodyDone.After(t.firstByte) {
+		timings.Transfer = bodyDone.Sub(t.firstByte)
+	}
+	return &timings
+}
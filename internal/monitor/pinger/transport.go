@@ -0,0 +1,148 @@
+package pinger
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// httpClients are the clients built for one set of transport settings.
+type httpClients struct {
+	client           *http.Client
+	noRedirectClient *http.Client
+	roots            *x509.CertPool // Nil means the system roots
+}
+
+// transportIdleTTL is how long clients stay cached after their last use, so
+// the settings of edited or removed services don't pin a transport for the
+// life of the process. Services checked less often just rebuild theirs.
+const transportIdleTTL = 10 * time.Minute
+
+// cachedClients are cached clients and when a check last used them.
+type cachedClients struct {
+	*httpClients
+	lastUsed time.Time
+}
+
+// transportCache shares clients between services with identical transport
+// settings, so thousands of services don't each build their own transport,
+// CA pool and client certificate. Connections themselves are never pooled,
+// see newHTTPClients.
+type transportCache struct {
+	mu        sync.Mutex
+	clients   map[[sha256.Size]byte]*cachedClients
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newTransportCache() *transportCache {
+	return &transportCache{
+		clients: make(map[[sha256.Size]byte]*cachedClients),
+		now:     time.Now,
+	}
+}
+
+// get returns the clients for cfg, building them on first use. The settings
+// are keyed by hash so private keys aren't kept around as map keys.
+func (c *transportCache) get(cfg *domain.TransportConfig) (*httpClients, error) {
+	var key [sha256.Size]byte
+	if cfg != nil {
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, err
+		}
+		key = sha256.Sum256(data)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= transportIdleTTL {
+		c.sweepLocked(now)
+	}
+
+	if cached, ok := c.clients[key]; ok {
+		cached.lastUsed = now
+		return cached.httpClients, nil
+	}
+	clients, err := newHTTPClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[key] = &cachedClients{httpClients: clients, lastUsed: now}
+	return clients, nil
+}
+
+// sweepLocked drops the clients unused for transportIdleTTL. Checks still
+// holding them finish normally.
+func (c *transportCache) sweepLocked(now time.Time) {
+	for key, cached := range c.clients {
+		if now.Sub(cached.lastUsed) >= transportIdleTTL {
+			cached.client.CloseIdleConnections()
+			delete(c.clients, key)
+		}
+	}
+	c.lastSweep = now
+}
+
+// newHTTPClients builds a transport for cfg. Keep-alives are disabled: every
+// check opens its own connection, so it measures DNS, connect and TLS, dials
+// the IP family it was asked for and records the address it reached.
+func newHTTPClients(cfg *domain.TransportConfig) (*httpClients, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	transport.DialContext = dialContext
+
+	clients := &httpClients{}
+	if cfg != nil {
+		if cfg.Proxy != "" {
+			proxyURL, err := url.Parse(cfg.Proxy)
+			if err != nil {
+				return nil, err
+			}
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+
+		tlsConfig := &tls.Config{
+			MinVersion:         cfg.TLSVersion(),
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}
+		if cfg.CACert != "" {
+			roots, err := x509.SystemCertPool()
+			if err != nil {
+				roots = x509.NewCertPool()
+			}
+			if !roots.AppendCertsFromPEM([]byte(cfg.CACert)) {
+				return nil, errors.New("ca_cert contains no valid PEM certificates")
+			}
+			tlsConfig.RootCAs = roots
+			clients.roots = roots
+		}
+		if cfg.ClientCert != "" {
+			cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	clients.client = &http.Client{Transport: transport}
+	clients.noRedirectClient = &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return clients, nil
+}
@@ -0,0 +1,47 @@
+package pinger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+func TestTransportCacheSharesAndEvicts(t *testing.T) {
+	now := time.Now()
+	cache := newTransportCache()
+	cache.now = func() time.Time { return now }
+
+	proxied := &domain.TransportConfig{Proxy: "http://proxy.internal:3128"}
+	first, err := cache.get(proxied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	same, err := cache.get(&domain.TransportConfig{Proxy: "http://proxy.internal:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != same {
+		t.Error("identical settings got separate transports")
+	}
+
+	// The proxied service is edited to drop its proxy; only the direct
+	// transport keeps being used
+	for range 3 {
+		now = now.Add(transportIdleTTL / 2)
+		if _, err := cache.get(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(cache.clients) != 1 {
+		t.Fatalf("%d transports cached, want the direct one only", len(cache.clients))
+	}
+
+	again, err := cache.get(proxied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == first {
+		t.Error("evicted transport was handed out again")
+	}
+}
@@ -0,0 +1,132 @@
+package pinger
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fasthttp/websocket"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type WSChecker struct{}
+
+func NewWSChecker() *WSChecker {
+	return &WSChecker{}
+}
+
+// Check performs the upgrade handshake and, when configured, sends a message
+// and waits for a matching reply. Non-matching messages are skipped until the
+// timeout expires.
+func (c *WSChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	start := time.Now()
+	cfg := service.Config.WS
+
+	ctx, cancel := context.WithTimeout(ctx, service.GetTimeout())
+	defer cancel()
+
+	header := http.Header{}
+	dialer := &websocket.Dialer{NetDialContext: dialContext}
+	if cfg != nil {
+		for k, v := range cfg.Headers {
+			header.Set(k, v)
+		}
+		dialer.Subprotocols = cfg.Subprotocols
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, service.URL, header)
+	handshake := time.Since(start)
+
+	result := domain.CheckResult{
+		ServiceID: service.ID,
+		CheckedAt: start,
+		Latency:   handshake,
+		WS:        &domain.WSTimings{Handshake: handshake},
+	}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	if err != nil {
+		result.FailureKind = classifyError(err)
+		result.ErrorMessage = errorMessage(err, service)
+		if resp != nil {
+			result.FailureKind = domain.FailureHTTPStatus
+			result.ErrorMessage = fmt.Sprintf("upgrade failed with %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+		if u, perr := url.Parse(service.URL); perr == nil {
+			result.TLS = tlsInfoFromError(err, u.Hostname(), nil)
+		}
+		return result
+	}
+	defer conn.Close()
+
+	if tlsConn, ok := conn.UnderlyingConn().(*tls.Conn); ok {
+		state := tlsConn.ConnectionState()
+		result.TLS = inspectCertificates(state.PeerCertificates, state.ServerName, nil)
+	}
+
+	if cfg.ExpectsReply() {
+		roundTrip, err := exchange(ctx, conn, cfg)
+		result.WS.RoundTrip = roundTrip
+		result.Latency += roundTrip
+		if err != nil {
+			result.FailureKind = classifyError(err)
+			result.ErrorMessage = errorMessage(err, service)
+			return result
+		}
+	}
+
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	result.Success = true
+	return result
+}
+
+// exchange sends cfg.Send, if any, and reads until a message matches the
+// expectation. It returns the time from sending to the matching reply.
+// Messages are capped at maxBodySize like HTTP bodies.
+func exchange(ctx context.Context, conn *websocket.Conn, cfg *domain.WSConfig) (time.Duration, error) {
+	conn.SetReadLimit(maxBodySize)
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetReadDeadline(deadline)
+		conn.SetWriteDeadline(deadline)
+	}
+
+	var re *regexp.Regexp
+	if cfg.ExpectRegex != "" {
+		var err error
+		if re, err = regexp.Compile(cfg.ExpectRegex); err != nil {
+			return 0, withKind(domain.FailureConfig, fmt.Errorf("invalid expect_regex: %w", err))
+		}
+	}
+
+	sent := time.Now()
+	if cfg.Send != "" {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(cfg.Send)); err != nil {
+			return time.Since(sent), withKind(domain.FailureProtocol, fmt.Errorf("failed to send message: %w", err))
+		}
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if errors.Is(err, websocket.ErrReadLimit) {
+			return time.Since(sent), withKind(domain.FailureProtocol, fmt.Errorf("reply exceeds %d bytes", maxBodySize))
+		}
+		if err != nil {
+			return time.Since(sent), withKind(domain.FailureProtocol, fmt.Errorf("no matching reply: %w", err))
+		}
+		if cfg.Expect != "" && !strings.Contains(string(msg), cfg.Expect) {
+			continue
+		}
+		if re != nil && !re.Match(msg) {
+			continue
+		}
+		return time.Since(sent), nil
+	}
+}
@@ -0,0 +1,105 @@
+package pinger
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/umutaraz/pulseguard/internal/adapter/handler/websocket"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// startWSServer serves the dashboard's websocket hub on a local port and
+// keeps broadcasting message to its clients. It returns the /ws URL.
+func startWSServer(t *testing.T, message domain.CheckResult) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub := websocket.NewHub()
+	go hub.Run()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use("/ws", websocket.UpgradeMiddleware)
+	app.Get("/ws", websocket.NewWebSocketHandler(hub))
+	go app.Listener(lis)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				hub.BroadcastCheckResult(message)
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		app.Shutdown()
+	})
+	return "ws://" + lis.Addr().String() + "/ws"
+}
+
+func TestWSCheckerAgainstLocalServer(t *testing.T) {
+	url := startWSServer(t, domain.CheckResult{ErrorMessage: "pong"})
+	large := startWSServer(t, domain.CheckResult{ErrorMessage: strings.Repeat("x", maxBodySize)})
+
+	tests := []struct {
+		name     string
+		target   string
+		config   *domain.WSConfig
+		wantOK   bool
+		wantKind domain.FailureKind
+		wantErr  string
+	}{
+		{name: "handshake", target: url, wantOK: true},
+		{name: "upgrade rejected", target: strings.TrimSuffix(url, "/ws") + "/missing", wantKind: domain.FailureHTTPStatus},
+		{name: "expected reply", target: url, config: &domain.WSConfig{Send: "ping", Expect: `"error_message":"pong"`}, wantOK: true},
+		{name: "expected regex", target: url, config: &domain.WSConfig{ExpectRegex: `"error_message":"p[a-z]+g"`}, wantOK: true},
+		{name: "no matching reply", target: url, config: &domain.WSConfig{Send: "ping", Expect: "never sent"}, wantKind: domain.FailureTimeout, wantErr: "no matching reply"},
+		{name: "reply over read limit", target: large, config: &domain.WSConfig{Expect: "x"}, wantKind: domain.FailureProtocol, wantErr: "exceeds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &domain.Service{
+				Name:     tt.name,
+				URL:      tt.target,
+				Type:     domain.ServiceTypeWS,
+				Interval: time.Minute,
+				Timeout:  time.Second,
+				Config:   domain.CheckConfig{WS: tt.config},
+			}
+			if err := service.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			result := NewWSChecker().Check(context.Background(), service)
+			if result.Success != tt.wantOK {
+				t.Fatalf("success = %v, want %v (%s)", result.Success, tt.wantOK, result.ErrorMessage)
+			}
+			if result.FailureKind != tt.wantKind {
+				t.Errorf("failure kind = %q, want %q (%s)", result.FailureKind, tt.wantKind, result.ErrorMessage)
+			}
+			if !strings.Contains(result.ErrorMessage, tt.wantErr) {
+				t.Errorf("error = %q, want it to mention %q", result.ErrorMessage, tt.wantErr)
+			}
+			if result.WS == nil || result.WS.Handshake <= 0 {
+				t.Errorf("handshake latency not recorded: %+v", result.WS)
+			}
+			if tt.config.ExpectsReply() && tt.wantOK && result.WS.RoundTrip <= 0 {
+				t.Error("round trip latency not recorded")
+			}
+		})
+	}
+}
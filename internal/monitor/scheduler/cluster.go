@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+	"github.com/umutaraz/pulseguard/internal/core/ports"
+)
+
+const (
+	leaveTimeout             = 5 * time.Second  // Bounds how long Shutdown waits to deregister the node
+	defaultRebalanceInterval = 10 * time.Second // Used when SetMembership gets no interval
+)
+
+// SetMembership makes the engine one node of a cluster. It then monitors only
+// the services the hash ring assigns to it and rebalances every interval.
+// Must be called before LoadAndStart; without it the engine runs standalone.
+func (e *MonitoringEngine) SetMembership(membership ports.ClusterMembership, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultRebalanceInterval
+	}
+	e.membership = membership
+	e.rebalanceInterval = interval
+}
+
+// ownsLocked reports whether this node is responsible for id.
+func (e *MonitoringEngine) ownsLocked(id uuid.UUID) bool {
+	return e.ring == nil || e.ring.owner(id) == e.membership.NodeID()
+}
+
+// joinCluster registers the node, claims its share of services and keeps
+// rebalancing until the engine shuts down.
+func (e *MonitoringEngine) joinCluster(ctx context.Context) error {
+	if err := e.membership.Join(e.ctx); err != nil {
+		return err
+	}
+	if err := e.rebalance(ctx); err != nil {
+		return err
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.rebalanceInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-e.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.rebalance(e.ctx); err != nil && e.ctx.Err() == nil {
+					slog.Error("Cluster: Rebalance failed", "node", e.membership.NodeID(), "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// rebalance rebuilds the ring from the live members and converges the
+// monitors on the services this node owns. Services created, edited or
+// deleted through another node are picked up here as well.
+func (e *MonitoringEngine) rebalance(ctx context.Context) error {
+	members, err := e.membership.Members(ctx)
+	if err != nil {
+		return err
+	}
+	self := e.membership.NodeID()
+	if !slices.Contains(members, self) {
+		// Our key lapsed; keep our share until the heartbeat restores it
+		members = append(members, self)
+	}
+
+	services, err := e.serviceRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	if e.ring == nil || !e.ring.sameNodes(members) {
+		slog.Info("Cluster: Membership changed", "node", self, "members", members)
+		e.ring = newHashRing(members)
+	}
+	ring := e.ring
+	current := make(map[uuid.UUID]*domain.Service, len(e.monitors))
+	for id, m := range e.monitors {
+		current[id] = m.service
+	}
+	e.mu.Unlock()
+
+	owned := make(map[uuid.UUID]bool)
+	for _, s := range services {
+		if ring.owner(s.ID) != self {
+			continue
+		}
+		owned[s.ID] = true
+		if prev, ok := current[s.ID]; !ok {
+			e.startMonitor(s, true)
+		} else if !prev.SameDefinition(s) {
+			e.UpdateMonitorForService(s)
+		}
+	}
+	for id := range current {
+		if !owned[id] {
+			e.StopMonitorForService(id)
+		}
+	}
+	return nil
+}
+
+// leaveCluster deregisters the node so the others take over its services
+// without waiting for its heartbeat to expire.
+func (e *MonitoringEngine) leaveCluster() {
+	ctx, cancel := context.WithTimeout(context.Background(), leaveTimeout)
+	defer cancel()
+	if err := e.membership.Leave(ctx); err != nil {
+		slog.Error("Cluster: Failed to leave", "node", e.membership.NodeID(), "error", err)
+	}
+}
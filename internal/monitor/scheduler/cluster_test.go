@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	cluster "github.com/umutaraz/pulseguard/internal/adapter/cluster/memory"
+	storage "github.com/umutaraz/pulseguard/internal/adapter/storage/memory"
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+type nopChecker struct{}
+
+func (nopChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	return domain.CheckResult{ServiceID: service.ID, CheckedAt: time.Now(), Success: true}
+}
+
+func quietLogs(t *testing.T) {
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+}
+
+func seedServices(t *testing.T, n int) *storage.InMemoryServiceRepository {
+	repo := storage.NewInMemoryServiceRepository()
+	for i := 0; i < n; i++ {
+		s := domain.NewService(fmt.Sprintf("svc-%d", i), "http://example.invalid", domain.ServiceTypeHTTP, time.Hour, false)
+		if err := repo.Create(context.Background(), s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func startNode(t *testing.T, registry *cluster.Registry, repo *storage.InMemoryServiceRepository, id string) *MonitoringEngine {
+	e := NewMonitoringEngine(repo, nopChecker{}, config.SchedulerConfig{Workers: 2})
+	e.SetMembership(registry.Member(id), time.Hour)
+	if err := e.LoadAndStart(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func monitored(e *MonitoringEngine) map[uuid.UUID]bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ids := make(map[uuid.UUID]bool, len(e.monitors))
+	for id := range e.monitors {
+		ids[id] = true
+	}
+	return ids
+}
+
+// assertPartitioned fails unless every service is monitored by exactly one engine.
+func assertPartitioned(t *testing.T, repo *storage.InMemoryServiceRepository, engines ...*MonitoringEngine) {
+	t.Helper()
+	services, _ := repo.GetAll(context.Background())
+	owners := make(map[uuid.UUID]int)
+	for _, e := range engines {
+		for id := range monitored(e) {
+			owners[id]++
+		}
+	}
+	for _, s := range services {
+		if owners[s.ID] != 1 {
+			t.Fatalf("service %s monitored by %d nodes, want 1", s.Name, owners[s.ID])
+		}
+	}
+	if len(owners) != len(services) {
+		t.Fatalf("%d services monitored, want %d", len(owners), len(services))
+	}
+}
+
+func rebalanceAll(t *testing.T, engines ...*MonitoringEngine) {
+	t.Helper()
+	for _, e := range engines {
+		if err := e.rebalance(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestClusterPartitionsServices(t *testing.T) {
+	quietLogs(t)
+	repo := seedServices(t, 300)
+	registry := cluster.NewRegistry()
+
+	a := startNode(t, registry, repo, "node-a")
+	defer a.Shutdown()
+	b := startNode(t, registry, repo, "node-b")
+	defer b.Shutdown()
+	c := startNode(t, registry, repo, "node-c")
+	defer c.Shutdown()
+
+	// Earlier nodes learn about later ones on their next rebalance
+	rebalanceAll(t, a, b, c)
+	assertPartitioned(t, repo, a, b, c)
+
+	for _, e := range []*MonitoringEngine{a, b, c} {
+		if n := len(monitored(e)); n < 50 {
+			t.Errorf("node %s owns %d of 300 services, expected a fair share", e.membership.NodeID(), n)
+		}
+	}
+}
+
+func TestClusterRebalancesWhenNodeDies(t *testing.T) {
+	quietLogs(t)
+	repo := seedServices(t, 100)
+	registry := cluster.NewRegistry()
+
+	a := startNode(t, registry, repo, "node-a")
+	defer a.Shutdown()
+	b := startNode(t, registry, repo, "node-b")
+	defer b.Shutdown()
+	c := startNode(t, registry, repo, "node-c")
+	rebalanceAll(t, a, b, c)
+
+	keptA := monitored(a)
+	c.Shutdown()
+	rebalanceAll(t, a, b)
+	assertPartitioned(t, repo, a, b)
+
+	// Consistent hashing only moves the departed node's services
+	for id := range keptA {
+		if !monitored(a)[id] {
+			t.Fatalf("service %s moved off a surviving node", id)
+		}
+	}
+}
+
+func TestClusterPicksUpServicesFromOtherNodes(t *testing.T) {
+	quietLogs(t)
+	repo := seedServices(t, 20)
+	registry := cluster.NewRegistry()
+
+	a := startNode(t, registry, repo, "node-a")
+	defer a.Shutdown()
+	b := startNode(t, registry, repo, "node-b")
+	defer b.Shutdown()
+	rebalanceAll(t, a, b)
+
+	// Registered through node a's API; whichever node owns it starts it
+	s := domain.NewService("late", "http://example.invalid", domain.ServiceTypeHTTP, time.Hour, false)
+	if err := repo.Create(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+	a.StartMonitorForService(s)
+	rebalanceAll(t, a, b)
+	assertPartitioned(t, repo, a, b)
+
+	if err := repo.Delete(context.Background(), s.ID); err != nil {
+		t.Fatal(err)
+	}
+	rebalanceAll(t, a, b)
+	if monitored(a)[s.ID] || monitored(b)[s.ID] {
+		t.Fatal("deleted service still monitored")
+	}
+}
+
+func TestStandaloneEngineMonitorsEverything(t *testing.T) {
+	quietLogs(t)
+	repo := seedServices(t, 10)
+
+	e := NewMonitoringEngine(repo, nopChecker{}, config.SchedulerConfig{Workers: 2})
+	defer e.Shutdown()
+	if err := e.LoadAndStart(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assertPartitioned(t, repo, e)
+}
+
+func TestClusterRebalanceIgnoresStatusChanges(t *testing.T) {
+	quietLogs(t)
+	repo := seedServices(t, 1)
+	registry := cluster.NewRegistry()
+
+	a := startNode(t, registry, repo, "node-a")
+	defer a.Shutdown()
+
+	services, _ := repo.GetAll(context.Background())
+	id := services[0].ID
+	current := func() *domain.Service {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return a.monitors[id].service
+	}
+	before := current()
+
+	// A status transition stores a fresh copy with a new updated_at
+	transitioned := *services[0]
+	transitioned.Status = domain.StatusDown
+	transitioned.UpdatedAt = time.Now().Add(time.Minute)
+	if err := repo.Update(context.Background(), &transitioned); err != nil {
+		t.Fatal(err)
+	}
+	rebalanceAll(t, a)
+	if current() != before {
+		t.Fatal("status change replaced the monitored definition")
+	}
+
+	edited := transitioned
+	edited.Interval = 2 * time.Hour
+	if err := repo.Update(context.Background(), &edited); err != nil {
+		t.Fatal(err)
+	}
+	rebalanceAll(t, a)
+	if got := current(); got.Interval != 2*time.Hour {
+		t.Fatalf("interval = %s after edit, want 2h", got.Interval)
+	}
+}
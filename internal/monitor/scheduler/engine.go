@@ -1,106 +1,442 @@
 package scheduler
 
 import (
+	"container/heap"
 	"context"
 	"log/slog"
+	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/config"
 	"github.com/umutaraz/pulseguard/internal/core/domain"
 	"github.com/umutaraz/pulseguard/internal/core/ports"
-	"github.com/umutaraz/pulseguard/internal/monitor/pinger"
+)
+
+// Pool sizes used when the configuration leaves them unset.
+const (
+	defaultWorkers   = 100
+	defaultQueueSize = 1000
+)
+
+const (
+	idleWait    = time.Minute            // Dispatcher sleep when nothing is scheduled
+	minInterval = time.Second            // Guards the schedule against zero intervals
+	queueRetry  = 100 * time.Millisecond // Wait before a check that found the queue full tries again
 )
 
 type ResultHandler func(result domain.CheckResult)
 
+// MonitoringEngine schedules every service on a single heap ordered by due
+// time. One dispatcher hands due checks to a bounded queue served by a fixed
+// pool of workers. A check that finds the queue full is retried shortly and
+// only skipped if the queue stays full until its next interval; one whose
+// previous run is still going is skipped right away.
+//
+// Services loaded at boot start at a phase offset hashed from their ID, first
+// checks are paced by startup_rate, and a check whose host already has
+// max_per_host checks in flight waits for one to finish.
 type MonitoringEngine struct {
-	serviceRepo    ports.ServiceRepository
-	pinger         *pinger.HTTPPinger
-	activeMonitors map[uuid.UUID]context.CancelFunc
-	mu             sync.Mutex
-	onResult       ResultHandler
+	serviceRepo ports.ServiceRepository
+	checker     ports.Checker
+	onResult    ResultHandler
+	workers     int
+	jitter      time.Duration
+	startGap    time.Duration // Minimum spacing of first checks, 0 for none
+	maxPerHost  int
+
+	membership        ports.ClusterMembership // Nil when running standalone
+	rebalanceInterval time.Duration
+
+	mu        sync.Mutex
+	monitors  map[uuid.UUID]*monitor
+	schedule  schedule
+	nextStart time.Time      // Earliest free startup slot
+	hostLoad  map[string]int // Queued and running checks per capped host
+	rng       *rand.Rand     // Jitter source
+	ring      *hashRing      // Service ownership, nil when standalone
+
+	jobs chan job
+	wake chan struct{}
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	startOnce sync.Once
+	wg        sync.WaitGroup
+
+	running  atomic.Int64
+	executed atomic.Uint64
+	late     atomic.Uint64
+	skipped  atomic.Uint64
+	overrun  atomic.Uint64
+	deferred atomic.Uint64
 }
 
+// LoadAndStart starts monitoring every stored service, or this node's share
+// of them when the engine is part of a cluster.
 func (e *MonitoringEngine) LoadAndStart(ctx context.Context) error {
+	e.startOnce.Do(e.start)
+
+	if e.membership != nil {
+		if err := e.joinCluster(ctx); err != nil {
+			return err
+		}
+		e.mu.Lock()
+		owned := len(e.monitors)
+		e.mu.Unlock()
+		slog.Info("Bootstrapped monitoring engine", "node", e.membership.NodeID(), "owned", owned, "workers", e.workers, "queue_size", cap(e.jobs))
+		return nil
+	}
+
 	services, err := e.serviceRepo.GetAll(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, s := range services {
-		e.StartMonitorForService(s)
+		e.startMonitor(s, true)
 	}
-	slog.Info("Bootstrapped monitoring engine", "count", len(services))
+	slog.Info("Bootstrapped monitoring engine", "count", len(services), "workers", e.workers, "queue_size", cap(e.jobs))
 	return nil
 }
 
-func NewMonitoringEngine(repo ports.ServiceRepository, pinger *pinger.HTTPPinger) *MonitoringEngine {
-	return &MonitoringEngine{
-		serviceRepo:    repo,
-		pinger:         pinger,
-		activeMonitors: make(map[uuid.UUID]context.CancelFunc),
+func NewMonitoringEngine(repo ports.ServiceRepository, checker ports.Checker, cfg config.SchedulerConfig) *MonitoringEngine {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
 
+	var startGap time.Duration
+	if cfg.StartupRate > 0 {
+		startGap = time.Duration(float64(time.Second) / cfg.StartupRate)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &MonitoringEngine{
+		serviceRepo: repo,
+		checker:     checker,
+		workers:     workers,
+		jitter:      cfg.Jitter,
+		startGap:    startGap,
+		maxPerHost:  cfg.MaxPerHost,
+		monitors:    make(map[uuid.UUID]*monitor),
+		hostLoad:    make(map[string]int),
+		rng:         rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		jobs:        make(chan job, queueSize),
+		wake:        make(chan struct{}, 1),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
 func (e *MonitoringEngine) SetResultHandler(handler ResultHandler) {
 	e.onResult = handler
 }
+
+// StartMonitorForService schedules service for an immediate first check,
+// replacing any monitor already running for it. In a cluster, services owned
+// by another node are left to that node.
 func (e *MonitoringEngine) StartMonitorForService(service *domain.Service) {
+	e.startMonitor(service, false)
+}
+
+// startMonitor schedules service. With spread, the first check is placed at
+// the service's phase offset instead of right away.
+func (e *MonitoringEngine) startMonitor(service *domain.Service, spread bool) {
+	e.startOnce.Do(e.start)
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	if cancel, exists := e.activeMonitors[service.ID]; exists {
-		cancel()
+	e.removeLocked(service.ID)
+	if !e.ownsLocked(service.ID) {
+		return
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	e.activeMonitors[service.ID] = cancel
+	first := time.Now()
+	if spread {
+		first = first.Add(phaseOffset(service.ID, service.Interval))
+	}
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	m := &monitor{
+		service: service,
+		ctx:     ctx,
+		cancel:  cancel,
+		base:    first,
+		next:    first.Add(jitter(e.rng, e.jitter)),
+	}
+	if e.maxPerHost > 0 {
+		m.host = targetHost(service)
+	}
+	e.monitors[service.ID] = m
+	heap.Push(&e.schedule, m)
+	e.notify()
+
+	slog.Info("Started monitoring for service", "service_id", service.ID, "url", service.DisplayURL(), "interval", service.Interval)
+	if service.Config.HTTP.SkipsVerify() {
+		slog.Warn("Audit: TLS certificate verification disabled for service", "service_id", service.ID, "service", service.Name)
+	}
+}
+
+// UpdateMonitorForService swaps the definition a running monitor checks with,
+// starting one if the service is not monitored yet. Checks already queued or
+// running finish on the old definition. A new interval counts from the last
+// scheduled check, so the cadence adjusts without restarting the monitor.
+func (e *MonitoringEngine) UpdateMonitorForService(service *domain.Service) {
+	e.mu.Lock()
+	m, exists := e.monitors[service.ID]
+	if !exists || !e.ownsLocked(service.ID) {
+		e.mu.Unlock()
+		e.StartMonitorForService(service)
+		return
+	}
 
-	slog.Info("Started monitoring for service", "service_id", service.ID, "url", service.URL, "interval", service.Interval)
+	old := m.service
+	m.service = service
+	if e.maxPerHost > 0 {
+		m.host = targetHost(service)
+	}
+	if service.Interval != old.Interval && m.index >= 0 {
+		last := m.base.Add(-max(old.Interval, minInterval))
+		m.base = last.Add(max(service.Interval, minInterval))
+		if now := time.Now(); m.base.Before(now) {
+			m.base = now
+		}
+		m.next = m.base.Add(jitter(e.rng, e.jitter))
+		heap.Fix(&e.schedule, m.index)
+		e.notify()
+	}
+	e.mu.Unlock()
 
-	go e.monitorLoop(ctx, service)
+	slog.Info("Updated monitoring for service", "service_id", service.ID, "url", service.DisplayURL(), "interval", service.Interval)
+	if service.Config.HTTP.SkipsVerify() {
+		slog.Warn("Audit: TLS certificate verification disabled for service", "service_id", service.ID, "service", service.Name)
+	}
 }
 
 func (e *MonitoringEngine) StopMonitorForService(id uuid.UUID) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	if cancel, exists := e.activeMonitors[id]; exists {
-		cancel()
-		delete(e.activeMonitors, id)
+	if e.removeLocked(id) {
+		e.notify()
 		slog.Info("Stopped monitoring for service", "service_id", id)
 	}
 }
 
-func (e *MonitoringEngine) monitorLoop(ctx context.Context, service *domain.Service) {
-	ticker := time.NewTicker(service.Interval)
-	defer ticker.Stop()
+// Shutdown stops scheduling, cancels in-flight checks and waits for the
+// workers to return. A cluster node also leaves the cluster.
+func (e *MonitoringEngine) Shutdown() {
+	e.cancel()
+	e.wg.Wait()
+	if e.membership != nil {
+		e.leaveCluster()
+	}
+}
+
+// Stats reports the pool's current load and lifetime counters.
+func (e *MonitoringEngine) Stats() domain.SchedulerStats {
+	e.mu.Lock()
+	monitored := len(e.monitors)
+	e.mu.Unlock()
+
+	return domain.SchedulerStats{
+		Workers:   e.workers,
+		QueueSize: cap(e.jobs),
+		Monitored: monitored,
+		Queued:    len(e.jobs),
+		Running:   e.running.Load(),
+		Executed:  e.executed.Load(),
+		Late:      e.late.Load(),
+		Skipped:   e.skipped.Load(),
+		Overrun:   e.overrun.Load(),
+		Deferred:  e.deferred.Load(),
+	}
+}
+
+func (e *MonitoringEngine) start() {
+	e.wg.Add(e.workers + 1)
+	go e.dispatch()
+	for i := 0; i < e.workers; i++ {
+		go e.work()
+	}
+}
+
+// removeLocked unschedules the monitor of id and cancels its checks.
+func (e *MonitoringEngine) removeLocked(id uuid.UUID) bool {
+	m, exists := e.monitors[id]
+	if !exists {
+		return false
+	}
+	m.cancel()
+	if m.index >= 0 {
+		heap.Remove(&e.schedule, m.index)
+	}
+	delete(e.monitors, id)
+	return true
+}
+
+// notify wakes the dispatcher so it re-reads the head of the schedule.
+func (e *MonitoringEngine) notify() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch queues due checks and sleeps until the next one is due.
+func (e *MonitoringEngine) dispatch() {
+	defer e.wg.Done()
 
-	e.performCheck(ctx, service)
+	timer := time.NewTimer(idleWait)
+	defer timer.Stop()
 
 	for {
+		e.mu.Lock()
+		wait := e.dispatchDueLocked(time.Now())
+		e.mu.Unlock()
+
+		timer.Reset(wait)
 		select {
-		case <-ctx.Done():
+		case <-e.ctx.Done():
 			return
-		case <-ticker.C:
-			e.performCheck(ctx, service)
+		case <-e.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatchDueLocked queues every check due at now and returns how long to
+// wait for the next one.
+func (e *MonitoringEngine) dispatchDueLocked(now time.Time) time.Duration {
+	for len(e.schedule) > 0 {
+		m := e.schedule[0]
+		if m.next.After(now) {
+			return m.next.Sub(now)
+		}
+
+		if e.postponeLocked(m, now) {
+			heap.Fix(&e.schedule, 0)
+			continue
+		}
+
+		interval := max(m.service.Interval, minInterval)
+		switch {
+		case m.busy:
+			e.overrun.Add(1)
+			slog.Warn("Check overrun, skipping", "service", m.service.Name, "interval", m.service.Interval)
+		default:
+			select {
+			case e.jobs <- job{monitor: m, service: m.service, host: m.host}:
+				m.busy = true
+				m.late = false
+				if m.host != "" {
+					e.hostLoad[m.host]++
+				}
+			default:
+				if !m.late {
+					m.late = true
+					e.late.Add(1)
+				}
+				if retry := now.Add(queueRetry); retry.Before(m.base.Add(interval)) {
+					m.next = retry
+					heap.Fix(&e.schedule, 0)
+					continue
+				}
+				m.late = false
+				e.skipped.Add(1)
+				slog.Warn("Check queue full for a whole interval, skipping", "service", m.service.Name)
+			}
+		}
+
+		// Stay on the service's cadence; ticks missed while the dispatcher lagged are not replayed
+		m.base = m.base.Add(interval)
+		if !m.base.After(now) {
+			m.base = now.Add(interval)
+		}
+		m.next = m.base.Add(jitter(e.rng, e.jitter))
+		heap.Fix(&e.schedule, 0)
+	}
+	return idleWait
+}
+
+// postponeLocked moves a due check to a later time when it has to wait for a
+// startup slot or for its host to drop below max_per_host.
+func (e *MonitoringEngine) postponeLocked(m *monitor, now time.Time) bool {
+	if !m.started {
+		m.started = true
+		if e.startGap > 0 {
+			slot := now
+			if e.nextStart.After(slot) {
+				slot = e.nextStart
+			}
+			e.nextStart = slot.Add(e.startGap)
+			if slot.After(now) {
+				m.base, m.next = slot, slot
+				return true
+			}
+		}
+	}
+
+	if m.host != "" && !m.busy && e.hostLoad[m.host] >= e.maxPerHost {
+		e.deferred.Add(1)
+		m.next = now.Add(hostRetry)
+		return true
+	}
+	return false
+}
+
+func (e *MonitoringEngine) work() {
+	defer e.wg.Done()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case j := <-e.jobs:
+			if j.monitor.ctx.Err() == nil {
+				e.running.Add(1)
+				e.performCheck(j.monitor.ctx, j.service)
+				e.running.Add(-1)
+				e.executed.Add(1)
+			}
+			e.finish(j)
+		}
+	}
+}
+
+// finish releases the monitor and host slot of a completed job.
+func (e *MonitoringEngine) finish(j job) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	j.monitor.busy = false
+	if j.host != "" {
+		if e.hostLoad[j.host]--; e.hostLoad[j.host] <= 0 {
+			delete(e.hostLoad, j.host)
 		}
 	}
 }
 
 func (e *MonitoringEngine) performCheck(ctx context.Context, service *domain.Service) {
-	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second) 
+	checkCtx, cancel := context.WithTimeout(ctx, service.GetTimeout())
 	defer cancel()
 
-	result := e.pinger.Ping(checkCtx, service)
-	
-	slog.Info("Health Check", 
-		"service", service.Name, 
-		"url", service.URL, 
-		"status_code", result.StatusCode, 
-		"latency", result.Latency, 
+	result := e.checker.Check(checkCtx, service)
+
+	slog.Info("Health Check",
+		"service", service.Name,
+		"type", service.Type,
+		"url", service.DisplayURL(),
+		"status_code", result.StatusCode,
+		"latency", result.Latency,
 		"success", result.Success,
 	)
 
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// sleepChecker stands in for a network check of fixed latency.
+type sleepChecker struct {
+	latency time.Duration
+}
+
+func (c sleepChecker) Check(ctx context.Context, service *domain.Service) domain.CheckResult {
+	select {
+	case <-time.After(c.latency):
+	case <-ctx.Done():
+	}
+	return domain.CheckResult{ServiceID: service.ID, CheckedAt: time.Now(), Latency: c.latency, Success: true}
+}
+
+// BenchmarkEngine10kServices schedules 10,000 services checked every second
+// with 5ms of simulated latency and measures completed checks. Goroutines stay
+// at the pool size no matter how many services are scheduled, and the burst of
+// first checks overflowing the default queue is retried rather than skipped.
+func BenchmarkEngine10kServices(b *testing.B) {
+	const services = 10000
+
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(prev)
+
+	engine := NewMonitoringEngine(nil, sleepChecker{latency: 5 * time.Millisecond}, config.SchedulerConfig{
+		Workers: 256,
+	})
+	defer engine.Shutdown()
+
+	var completed atomic.Int64
+	done := make(chan struct{})
+	target := int64(b.N)
+	engine.SetResultHandler(func(domain.CheckResult) {
+		if completed.Add(1) == target {
+			close(done)
+		}
+	})
+
+	goroutinesBefore := runtime.NumGoroutine()
+	b.ResetTimer()
+	for i := 0; i < services; i++ {
+		engine.StartMonitorForService(&domain.Service{
+			ID:       uuid.New(),
+			Name:     fmt.Sprintf("bench-%d", i),
+			URL:      "http://bench.invalid",
+			Type:     domain.ServiceTypeHTTP,
+			Interval: time.Second,
+		})
+	}
+	<-done
+	b.StopTimer()
+
+	stats := engine.Stats()
+	b.ReportMetric(float64(runtime.NumGoroutine()-goroutinesBefore), "goroutines")
+	b.ReportMetric(float64(stats.Late), "late")
+	b.ReportMetric(float64(stats.Skipped), "skipped")
+	b.ReportMetric(float64(stats.Overrun), "overrun")
+}
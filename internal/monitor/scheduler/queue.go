@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// monitor is the scheduling state of one service. Its definition can be
+// swapped while it runs; queued checks keep the one they were queued with.
+type monitor struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Guarded by MonitoringEngine.mu
+	service *domain.Service
+	host    string    // Target host for max_per_host, empty when not capped
+	base    time.Time // Slot on the service's cadence, before jitter
+	next    time.Time // When the next check is due
+	index   int       // Position in the schedule, -1 once removed
+	busy    bool      // A check is queued or running
+	started bool      // The first check has been given a startup slot
+	late    bool      // The due check found the queue full and is being retried
+}
+
+// job is a check handed to the worker pool, pinned to the definition and
+// host the monitor had when it was queued.
+type job struct {
+	monitor *monitor
+	service *domain.Service
+	host    string
+}
+
+// schedule is a min-heap of monitors ordered by their next due time.
+type schedule []*monitor
+
+func (s schedule) Len() int           { return len(s) }
+func (s schedule) Less(i, j int) bool { return s[i].next.Before(s[j].next) }
+
+func (s schedule) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+	s[i].index = i
+	s[j].index = j
+}
+
+func (s *schedule) Push(x any) {
+	m := x.(*monitor)
+	m.index = len(*s)
+	*s = append(*s, m)
+}
+
+func (s *schedule) Pop() any {
+	old := *s
+	n := len(old)
+	m := old[n-1]
+	old[n-1] = nil
+	m.index = -1
+	*s = old[:n-1]
+	return m
+}
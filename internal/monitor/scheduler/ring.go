@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// ringReplicas is the number of points each node takes on the ring; more
+// points even out the share of services per node.
+const ringReplicas = 128
+
+// hashRing assigns services to nodes by consistent hashing, so a node joining
+// or leaving only moves the services on its own arcs. Every node builds the
+// same ring from the same member list.
+type hashRing struct {
+	nodes  []string // Sorted member IDs
+	points []uint64
+	owners map[uint64]string
+}
+
+func newHashRing(nodes []string) *hashRing {
+	r := &hashRing{
+		nodes:  slices.Sorted(slices.Values(nodes)),
+		owners: make(map[uint64]string, len(nodes)*ringReplicas),
+	}
+	for _, node := range r.nodes {
+		for i := 0; i < ringReplicas; i++ {
+			p := ringHash([]byte(node + "#" + strconv.Itoa(i)))
+			r.points = append(r.points, p)
+			r.owners[p] = node
+		}
+	}
+	slices.Sort(r.points)
+	return r
+}
+
+// owner returns the node responsible for id, or "" on an empty ring.
+func (r *hashRing) owner(id uuid.UUID) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := ringHash(id[:])
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+func (r *hashRing) sameNodes(nodes []string) bool {
+	return slices.Equal(r.nodes, slices.Sorted(slices.Values(nodes)))
+}
+
+func ringHash(b []byte) uint64 {
+	sum := sha256.Sum256(b)
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// hostRetry is how long a check waits when its host is at max_per_host.
+const hostRetry = 250 * time.Millisecond
+
+// phaseOffset places id at a fixed point within interval, so services started
+// together spread over the interval and keep the same phase across restarts.
+func phaseOffset(id uuid.UUID, interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write(id[:])
+	return time.Duration(h.Sum64() % uint64(interval))
+}
+
+// jitter returns a random delay in [0, limit) drawn from rng.
+func jitter(rng *rand.Rand, limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rng.Int64N(int64(limit)))
+}
+
+// targetHost returns the host a check connects to, for URLs ("https://h/"),
+// DSNs and "host:port" targets alike. It is empty for services without a
+// network target, such as EXEC and PUSH.
+func targetHost(service *domain.Service) string {
+	switch service.Type {
+	case domain.ServiceTypePush, domain.ServiceTypeExec, domain.ServiceTypeDNS:
+		return ""
+	}
+	if u, err := url.Parse(service.URL); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	if host, _, err := net.SplitHostPort(service.URL); err == nil {
+		return host
+	}
+	return ""
+}
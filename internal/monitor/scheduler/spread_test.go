@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umutaraz/pulseguard/internal/config"
+	"github.com/umutaraz/pulseguard/internal/core/domain"
+)
+
+// fixedIDs returns n service IDs that are the same on every run.
+func fixedIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprint(i)))
+	}
+	return ids
+}
+
+// newManualEngine returns an engine whose workers never start, with a seeded
+// jitter source. Tests drive it through dispatchDueLocked and finish, playing
+// the part of the workers themselves.
+func newManualEngine(cfg config.SchedulerConfig) *MonitoringEngine {
+	e := NewMonitoringEngine(nil, nopChecker{}, cfg)
+	e.startOnce.Do(func() {})
+	e.rng = rand.New(rand.NewPCG(1, 2))
+	return e
+}
+
+func TestPhaseOffsetWithinInterval(t *testing.T) {
+	const buckets = 10
+	ids := fixedIDs(1000)
+
+	for _, interval := range []time.Duration{time.Second, time.Minute, 7 * time.Minute} {
+		counts := make([]int, buckets)
+		for _, id := range ids {
+			offset := phaseOffset(id, interval)
+			if offset < 0 || offset >= interval {
+				t.Fatalf("offset %v outside interval %v", offset, interval)
+			}
+			if again := phaseOffset(id, interval); again != offset {
+				t.Fatalf("offset of %s changed from %v to %v", id, offset, again)
+			}
+			counts[offset*buckets/interval]++
+		}
+		// 100 per bucket when spread evenly
+		for i, n := range counts {
+			if n < 50 {
+				t.Errorf("interval %v: %d offsets in bucket %d, services are bunched up", interval, n, i)
+			}
+		}
+	}
+
+	if offset := phaseOffset(ids[0], 0); offset != 0 {
+		t.Errorf("offset for zero interval = %v, want 0", offset)
+	}
+}
+
+func TestJitterWithinBound(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	const limit = 5 * time.Second
+
+	var highest time.Duration
+	for range 10000 {
+		d := jitter(rng, limit)
+		if d < 0 || d >= limit {
+			t.Fatalf("jitter %v outside [0, %v)", d, limit)
+		}
+		highest = max(highest, d)
+	}
+	if highest < limit*9/10 {
+		t.Errorf("highest jitter %v, the bound is never approached", highest)
+	}
+	if d := jitter(rng, 0); d != 0 {
+		t.Errorf("jitter without a bound = %v, want 0", d)
+	}
+}
+
+func TestFirstChecksFollowJitterAndStartupRate(t *testing.T) {
+	quietLogs(t)
+	e := newManualEngine(config.SchedulerConfig{Jitter: time.Second, StartupRate: 10, QueueSize: 100})
+
+	for _, id := range fixedIDs(5) {
+		e.StartMonitorForService(&domain.Service{ID: id, Name: id.String(), URL: "http://example.invalid", Type: domain.ServiceTypeHTTP, Interval: time.Hour})
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	start := time.Now()
+	for _, m := range e.monitors {
+		if delay := m.next.Sub(m.base); delay < 0 || delay >= time.Second {
+			t.Fatalf("first check jittered by %v, want [0, 1s)", delay)
+		}
+	}
+
+	// Once all are due, one first check starts every 100ms
+	now := start.Add(time.Second)
+	for want := 1; want <= 5; want++ {
+		e.dispatchDueLocked(now)
+		if len(e.jobs) != want {
+			t.Fatalf("%d checks started by %v, want %d", len(e.jobs), now.Sub(start), want)
+		}
+		now = now.Add(100 * time.Millisecond)
+	}
+}
+
+func TestMaxPerHostBoundsInFlightChecks(t *testing.T) {
+	quietLogs(t)
+	const maxPerHost = 3
+	e := newManualEngine(config.SchedulerConfig{MaxPerHost: maxPerHost, QueueSize: 100})
+
+	for i, id := range fixedIDs(20) {
+		host := "a.example.com"
+		if i%2 == 1 {
+			host = "b.example.com"
+		}
+		e.StartMonitorForService(&domain.Service{ID: id, Name: id.String(), URL: "https://" + host + "/health", Type: domain.ServiceTypeHTTP, Interval: time.Second})
+	}
+
+	// Each round, the workers finish one queued check at random and the
+	// dispatcher runs again
+	rng := rand.New(rand.NewPCG(3, 4))
+	var inFlight []job
+	now := time.Now()
+	for round := range 500 {
+		e.mu.Lock()
+		e.dispatchDueLocked(now)
+		e.mu.Unlock()
+		for len(e.jobs) > 0 {
+			inFlight = append(inFlight, <-e.jobs)
+		}
+
+		perHost := make(map[string]int)
+		for _, j := range inFlight {
+			if perHost[j.host]++; perHost[j.host] > maxPerHost {
+				t.Fatalf("round %d: %d checks in flight for %s, max_per_host is %d", round, perHost[j.host], j.host, maxPerHost)
+			}
+		}
+
+		if len(inFlight) > 0 {
+			i := rng.IntN(len(inFlight))
+			e.finish(inFlight[i])
+			inFlight = append(inFlight[:i], inFlight[i+1:]...)
+		}
+		now = now.Add(50 * time.Millisecond)
+	}
+
+	if e.executed.Load() != 0 {
+		t.Fatal("workers ran checks")
+	}
+	if e.deferred.Load() == 0 {
+		t.Error("no check was deferred, the cap was never reached")
+	}
+}
@@ -151,6 +151,11 @@ $(function () {
         ws.onopen = () => toastr.success('Real-time connection established');
         ws.onmessage = (event) => {
             const data = JSON.parse(event.data);
+            if (data.type === 'content_change') {
+                const name = $('<div>').text(data.data.service_name).html();
+                toastr.info(`Content changed: ${name}`);
+                return;
+            }
             updateServiceRow(data);
         };
         ws.onclose = () => {